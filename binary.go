@@ -0,0 +1,77 @@
+package jogson
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// maxBinaryDepth is the maximum nesting depth of decoded binary formats, which protects the decoders from
+// running out of stack on malicious input
+const maxBinaryDepth = 10000
+
+// appendUint appends n as a big-endian unsigned integer of the given size in bytes
+func appendUint(buf []byte, n uint64, size int) []byte {
+	for i := size - 1; i >= 0; i-- {
+		buf = append(buf, byte(n>>(8*i)))
+	}
+	return buf
+}
+
+// binaryDecoder reads the input of the decoders of binary formats such as MessagePack and CBOR
+type binaryDecoder struct {
+	format string
+	data   []byte
+	offset int
+}
+
+// checkFloat returns an error for NaN and infinity, which cannot be represented as JSON
+func (d *binaryDecoder) checkFloat(f float64, start int) (any, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		d.offset = start
+		return nil, d.errorf("%v cannot be represented in JSON", f)
+	}
+	return f, nil
+}
+
+func (d *binaryDecoder) read(n int) ([]byte, error) {
+	if n > len(d.data)-d.offset {
+		return nil, d.unexpectedEnd()
+	}
+	data := d.data[d.offset : d.offset+n]
+	d.offset += n
+	return data, nil
+}
+
+// readUint reads a big-endian unsigned integer of the given size in bytes
+func (d *binaryDecoder) readUint(size int) (uint64, error) {
+	data, err := d.read(size)
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	for _, b := range data {
+		n = n<<8 | uint64(b)
+	}
+	return n, nil
+}
+
+// mapKey converts a decoded map key into a string. Numbers and bools are converted into their string form and
+// other keys cannot be represented in JSON.
+func (d *binaryDecoder) mapKey(key any, start int) (string, error) {
+	switch key.(type) {
+	case string, int, float64, *big.Int, bool:
+		return formatText(key, ""), nil
+	}
+	keyMapper := newMapperFromParsed(key)
+	d.offset = start
+	return "", d.errorf("map key of type %v cannot be represented in JSON", keyMapper.Kind())
+}
+
+func (d *binaryDecoder) errorf(format string, args ...any) error {
+	return createBinaryParseErr(d.format, d.offset, fmt.Sprintf(format, args...))
+}
+
+func (d *binaryDecoder) unexpectedEnd() error {
+	return createBinaryParseErr(d.format, len(d.data), "unexpected end of input")
+}
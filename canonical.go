@@ -0,0 +1,286 @@
+package jogson
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// CanonicalBytes returns the canonical JSON representation of the JsonObject as defined by the JSON
+// Canonicalization Scheme (RFC 8785): keys are sorted, numbers are formatted as in ECMAScript and strings
+// are minimally escaped. Identical documents always have identical canonical representations.
+func (o *JsonObject) CanonicalBytes() ([]byte, error) {
+	return canonicalize(o.object)
+}
+
+// CanonicalString returns the canonical JSON representation of the JsonObject as string. See CanonicalBytes.
+func (o *JsonObject) CanonicalString() (string, error) {
+	b, err := o.CanonicalBytes()
+	return string(b), err
+}
+
+// Fingerprint returns the hex encoded SHA-256 hash of the canonical representation of the JsonObject.
+func (o *JsonObject) Fingerprint() (string, error) {
+	return fingerprint(o.object)
+}
+
+// HMAC returns the hex encoded HMAC-SHA256 signature of the canonical representation of the JsonObject
+// with the given key.
+func (o *JsonObject) HMAC(key []byte) (string, error) {
+	return signHMAC(o.object, key)
+}
+
+// CanonicalBytes returns the canonical JSON representation of the JsonArray as defined by the JSON
+// Canonicalization Scheme (RFC 8785): keys are sorted, numbers are formatted as in ECMAScript and strings
+// are minimally escaped. Identical documents always have identical canonical representations.
+func (a *JsonArray) CanonicalBytes() ([]byte, error) {
+	return canonicalize(a.elements)
+}
+
+// CanonicalString returns the canonical JSON representation of the JsonArray as string. See CanonicalBytes.
+func (a *JsonArray) CanonicalString() (string, error) {
+	b, err := a.CanonicalBytes()
+	return string(b), err
+}
+
+// Fingerprint returns the hex encoded SHA-256 hash of the canonical representation of the JsonArray.
+func (a *JsonArray) Fingerprint() (string, error) {
+	return fingerprint(a.elements)
+}
+
+// HMAC returns the hex encoded HMAC-SHA256 signature of the canonical representation of the JsonArray
+// with the given key.
+func (a *JsonArray) HMAC(key []byte) (string, error) {
+	return signHMAC(a.elements, key)
+}
+
+// CanonicalBytes returns the canonical JSON representation of the JsonMapper as defined by the JSON
+// Canonicalization Scheme (RFC 8785): keys are sorted, numbers are formatted as in ECMAScript and strings
+// are minimally escaped. Identical documents always have identical canonical representations.
+func (m *JsonMapper) CanonicalBytes() ([]byte, error) {
+	return canonicalize(convertMapperToAny(*m))
+}
+
+// CanonicalString returns the canonical JSON representation of the JsonMapper as string. See CanonicalBytes.
+func (m *JsonMapper) CanonicalString() (string, error) {
+	b, err := m.CanonicalBytes()
+	return string(b), err
+}
+
+// Fingerprint returns the hex encoded SHA-256 hash of the canonical representation of the JsonMapper.
+func (m *JsonMapper) Fingerprint() (string, error) {
+	return fingerprint(convertMapperToAny(*m))
+}
+
+// HMAC returns the hex encoded HMAC-SHA256 signature of the canonical representation of the JsonMapper
+// with the given key.
+func (m *JsonMapper) HMAC(key []byte) (string, error) {
+	return signHMAC(convertMapperToAny(*m), key)
+}
+
+func canonicalize(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	err := writeCanonical(&buf, value)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func fingerprint(value any) (string, error) {
+	canonical, err := canonicalize(value)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(canonical)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func signHMAC(value any, key []byte) (string, error) {
+	canonical, err := canonicalize(value)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(canonical)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func writeCanonical(buf *bytes.Buffer, value any) error {
+	if members, ok := toMemberPtrs(value); ok {
+		keys := make([]string, 0, len(members))
+		for k := range members {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return lessUTF16(keys[i], keys[j])
+		})
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			err := writeCanonicalString(buf, k)
+			if err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			err = writeCanonicalPtr(buf, members[k])
+			if err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	}
+	if elements, ok := toElementPtrs(value); ok {
+		buf.WriteByte('[')
+		for i, element := range elements {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			err := writeCanonicalPtr(buf, element)
+			if err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	}
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case int:
+		return writeCanonicalNumber(buf, float64(v))
+	case float64:
+		return writeCanonicalNumber(buf, v)
+	case string:
+		return writeCanonicalString(buf, v)
+	default:
+		// values of other Go types are converted to their JSON representation first
+		jsonBytes, err := marshal(v)
+		if err != nil {
+			return createCanonicalizationErr(v)
+		}
+		var decoded any
+		err = unmarshal(jsonBytes, &decoded)
+		if err != nil {
+			return createCanonicalizationErr(v)
+		}
+		return writeCanonical(buf, decoded)
+	}
+	return nil
+}
+
+func writeCanonicalPtr(buf *bytes.Buffer, value *any) error {
+	if value == nil {
+		buf.WriteString("null")
+		return nil
+	}
+	return writeCanonical(buf, *value)
+}
+
+// writeCanonicalNumber writes f as ECMAScript's Number.prototype.toString() would
+func writeCanonicalNumber(buf *bytes.Buffer, f float64) error {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return createCanonicalizationErr(f)
+	}
+	if f == 0 {
+		buf.WriteByte('0')
+		return nil
+	}
+	if f < 0 {
+		buf.WriteByte('-')
+		f = -f
+	}
+	// shortest representation in the form d.ddde±x
+	formatted := strconv.FormatFloat(f, 'e', -1, 64)
+	mantissa, exponent, _ := strings.Cut(formatted, "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	exp, _ := strconv.Atoi(exponent)
+	k := len(digits)
+	n := exp + 1
+	switch {
+	case k <= n && n <= 21:
+		buf.WriteString(digits)
+		buf.WriteString(strings.Repeat("0", n-k))
+	case 0 < n && n <= 21:
+		buf.WriteString(digits[:n])
+		buf.WriteByte('.')
+		buf.WriteString(digits[n:])
+	case -6 < n && n <= 0:
+		buf.WriteString("0.")
+		buf.WriteString(strings.Repeat("0", -n))
+		buf.WriteString(digits)
+	default:
+		buf.WriteByte(digits[0])
+		if k > 1 {
+			buf.WriteByte('.')
+			buf.WriteString(digits[1:])
+		}
+		buf.WriteByte('e')
+		if n-1 > 0 {
+			buf.WriteByte('+')
+		}
+		buf.WriteString(strconv.Itoa(n - 1))
+	}
+	return nil
+}
+
+// writeCanonicalString writes s as a JSON string, escaping only what must be escaped
+func writeCanonicalString(buf *bytes.Buffer, s string) error {
+	if !utf8.ValidString(s) {
+		return createCanonicalizationErr(s)
+	}
+	const hexDigits = "0123456789abcdef"
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hexDigits[r>>4])
+				buf.WriteByte(hexDigits[r&0xf])
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return nil
+}
+
+// lessUTF16 compares a and b by their UTF-16 code units as required by RFC 8785
+func lessUTF16(a, b string) bool {
+	ua := utf16.Encode([]rune(a))
+	ub := utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
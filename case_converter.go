@@ -0,0 +1,167 @@
+package jogson
+
+import (
+	"strings"
+	"unicode"
+)
+
+// KeyCase represents a naming convention of JSON keys
+type KeyCase int
+
+const (
+	// SnakeCase represents keys such as "user_id"
+	SnakeCase KeyCase = iota
+	// CamelCase represents keys such as "userId"
+	CamelCase
+	// PascalCase represents keys such as "UserId"
+	PascalCase
+	// KebabCase represents keys such as "user-id"
+	KebabCase
+	// ScreamingSnakeCase represents keys such as "USER_ID"
+	ScreamingSnakeCase
+)
+
+// CaseConverter converts keys from one KeyCase to another. Acronyms, e.g. "ID" or "HTTP", are
+// written in upper case when converting to CamelCase or PascalCase, so that "user_id" becomes "userID"
+// instead of "userId". Acronyms are matched case-insensitively.
+type CaseConverter struct {
+	From     KeyCase
+	To       KeyCase
+	Acronyms []string
+}
+
+// NewCaseConverter returns a new CaseConverter that converts keys from the case from to the case to.
+func NewCaseConverter(from KeyCase, to KeyCase, acronyms ...string) CaseConverter {
+	return CaseConverter{From: from, To: to, Acronyms: acronyms}
+}
+
+// Reverse returns a CaseConverter that converts keys in the opposite direction, which makes it possible
+// to convert keys back to their original case, e.g. when bridging between a snake_case and a camelCase API.
+func (c CaseConverter) Reverse() CaseConverter {
+	return CaseConverter{From: c.To, To: c.From, Acronyms: c.Acronyms}
+}
+
+// Convert converts the key s from the case c.From to the case c.To.
+func (c CaseConverter) Convert(s string) string {
+	var words []string
+	switch c.From {
+	case SnakeCase, KebabCase, ScreamingSnakeCase:
+		words = splitBySeparators(s)
+	default:
+		words = splitWords(s)
+	}
+	return joinWords(words, c.To, c.Acronyms)
+}
+
+// ToSnakeCase converts the key s to snake_case, e.g. "HTTPServerID" becomes "http_server_id".
+func ToSnakeCase(s string) string {
+	return joinWords(splitWords(s), SnakeCase, nil)
+}
+
+// ToCamelCase converts the key s to camelCase, e.g. "user_id" becomes "userId".
+func ToCamelCase(s string) string {
+	return joinWords(splitWords(s), CamelCase, nil)
+}
+
+// ToPascalCase converts the key s to PascalCase, e.g. "user_id" becomes "UserId".
+func ToPascalCase(s string) string {
+	return joinWords(splitWords(s), PascalCase, nil)
+}
+
+// ToKebabCase converts the key s to kebab-case, e.g. "userId" becomes "user-id".
+func ToKebabCase(s string) string {
+	return joinWords(splitWords(s), KebabCase, nil)
+}
+
+// ToScreamingSnakeCase converts the key s to SCREAMING_SNAKE_CASE, e.g. "userId" becomes "USER_ID".
+func ToScreamingSnakeCase(s string) string {
+	return joinWords(splitWords(s), ScreamingSnakeCase, nil)
+}
+
+// isWordSeparator checks if r separates words in a key
+func isWordSeparator(r rune) bool {
+	return r == '_' || r == '-' || r == ' ' || r == '.'
+}
+
+// splitBySeparators splits s into words only by separators, keeping the case of each word
+func splitBySeparators(s string) []string {
+	return strings.FieldsFunc(s, isWordSeparator)
+}
+
+// splitWords splits s into words by separators and by case changes. A sequence of upper case
+// letters is regarded as an acronym, so "HTTPServer" is split into "HTTP" and "Server".
+func splitWords(s string) []string {
+	var words []string
+	for _, field := range splitBySeparators(s) {
+		runes := []rune(field)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			prev, curr := runes[i-1], runes[i]
+			isBoundary := false
+			if unicode.IsUpper(curr) {
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+					isBoundary = true
+				} else if unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+					isBoundary = true
+				}
+			}
+			if isBoundary {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// joinWords joins words into a single key in the given case
+func joinWords(words []string, keyCase KeyCase, acronyms []string) string {
+	var sb strings.Builder
+	for i, word := range words {
+		switch keyCase {
+		case SnakeCase, KebabCase, ScreamingSnakeCase:
+			if i > 0 {
+				if keyCase == KebabCase {
+					sb.WriteByte('-')
+				} else {
+					sb.WriteByte('_')
+				}
+			}
+			if keyCase == ScreamingSnakeCase {
+				sb.WriteString(strings.ToUpper(word))
+			} else {
+				sb.WriteString(strings.ToLower(word))
+			}
+		case CamelCase, PascalCase:
+			if i == 0 && keyCase == CamelCase {
+				sb.WriteString(strings.ToLower(word))
+			} else if isAcronym(word, acronyms) {
+				sb.WriteString(strings.ToUpper(word))
+			} else {
+				sb.WriteString(capitalize(word))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// isAcronym checks if word is one of the acronyms
+func isAcronym(word string, acronyms []string) bool {
+	for _, acronym := range acronyms {
+		if strings.EqualFold(word, acronym) {
+			return true
+		}
+	}
+	return false
+}
+
+// capitalize converts the first letter of word to upper case and the rest to lower case
+func capitalize(word string) string {
+	runes := []rune(strings.ToLower(word))
+	if len(runes) == 0 {
+		return word
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
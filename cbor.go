@@ -0,0 +1,479 @@
+package jogson
+
+import (
+	"bytes"
+	"encoding/base64"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	cborFormat = "cbor"
+
+	cborUnsigned = 0
+	cborNegative = 1
+	cborBytes    = 2
+	cborText     = 3
+	cborArray    = 4
+	cborMap      = 5
+	cborTag      = 6
+	cborSimple   = 7
+
+	cborTagDateTime       = 0
+	cborTagEpochTime      = 1
+	cborTagPositiveBignum = 2
+	cborTagNegativeBignum = 3
+
+	// cborIndefinite is the additional information of indefinite-length strings, arrays and maps
+	cborIndefinite = 31
+	cborBreak      = 0xff
+)
+
+// NewMapperFromCBOR decodes a CBOR (RFC 8949) value into a JsonMapper. Byte strings are converted into base64
+// strings, as in NewMapperFromMsgPack. Date/time strings (tag 0) are kept as strings and epoch times (tag 1)
+// are converted into RFC 3339 strings, which GetTime understands. Integers that do not fit into int, including
+// bignums (tags 2 and 3), are kept as numbers with all their digits, which GetBigInt returns. The content of other tags is converted without
+// the tag. Map keys that are numbers or bools are converted into their string form. If data is not valid
+// CBOR or contains values that cannot be represented as JSON, ParseErr is returned.
+func NewMapperFromCBOR(data []byte) (JsonMapper, error) {
+	d := &cborDecoder{binaryDecoder{format: cborFormat, data: data}}
+	value, err := d.decode(0)
+	if err != nil {
+		return JsonMapper{}, err
+	}
+	if d.offset < len(d.data) {
+		return JsonMapper{}, d.errorf("unexpected data after the top-level value")
+	}
+	return newMapperFromParsed(value), nil
+}
+
+// NewObjectFromCBOR decodes a CBOR map into a JsonObject. See NewMapperFromCBOR.
+func NewObjectFromCBOR(data []byte) (*JsonObject, error) {
+	mapper, err := NewMapperFromCBOR(data)
+	if err != nil {
+		return &JsonObject{}, err
+	}
+	return mapper.AsObject()
+}
+
+// NewArrayFromCBOR decodes a CBOR array into a JsonArray. See NewMapperFromCBOR.
+func NewArrayFromCBOR(data []byte) (*JsonArray, error) {
+	mapper, err := NewMapperFromCBOR(data)
+	if err != nil {
+		return &JsonArray{}, err
+	}
+	return mapper.AsArray()
+}
+
+// CBORBytes returns the deterministic CBOR encoding of the JsonObject. See JsonMapper.CBORBytes.
+func (o *JsonObject) CBORBytes() []byte {
+	return appendCBOR(nil, o.object)
+}
+
+// CBORBytes returns the deterministic CBOR encoding of the JsonArray. See JsonMapper.CBORBytes.
+func (a *JsonArray) CBORBytes() []byte {
+	return appendCBOR(nil, a.elements)
+}
+
+// CBORBytes returns the CBOR encoding of the JsonMapper underlying value. The encoding is deterministic as
+// defined in RFC 8949 section 4.2: integers, lengths and floats use their shortest form, all lengths are
+// definite and map keys are sorted by their encoding. Numbers without a fractional part are encoded as
+// integers. Integers that do not fit into 64 bits are encoded as bignums. Strings are always encoded as text
+// strings, so decoded times are encoded as their string form and not with tags.
+func (m *JsonMapper) CBORBytes() []byte {
+	return appendCBOR(nil, m.value)
+}
+
+// appendCBOR appends the CBOR encoding of value to buf
+func appendCBOR(buf []byte, value any) []byte {
+	switch v := value.(type) {
+	case nil:
+		return append(buf, 0xf6)
+	case bool:
+		if v {
+			return append(buf, 0xf5)
+		}
+		return append(buf, 0xf4)
+	case int:
+		return appendCBORInt(buf, int64(v))
+	case float64:
+		if v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64 {
+			return appendCBORInt(buf, int64(v))
+		}
+		return appendCBORFloat(buf, v)
+	case *big.Int:
+		return appendCBORBigInt(buf, v)
+	case string:
+		buf = appendCBORHead(buf, cborText, uint64(len(v)))
+		return append(buf, v...)
+	}
+	if members, ok := toMemberPtrs(value); ok {
+		keys := make([][]byte, 0, len(members))
+		encodedKeys := make(map[string]string, len(members))
+		for key := range members {
+			encoded := appendCBOR(nil, key)
+			keys = append(keys, encoded)
+			encodedKeys[string(encoded)] = key
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return bytes.Compare(keys[i], keys[j]) < 0
+		})
+		buf = appendCBORHead(buf, cborMap, uint64(len(members)))
+		for _, key := range keys {
+			buf = append(buf, key...)
+			buf = appendCBOR(buf, derefValue(members[encodedKeys[string(key)]]))
+		}
+		return buf
+	}
+	if elements, ok := toElementPtrs(value); ok {
+		buf = appendCBORHead(buf, cborArray, uint64(len(elements)))
+		for _, element := range elements {
+			buf = appendCBOR(buf, derefValue(element))
+		}
+		return buf
+	}
+	normalized, err := normalizeValue(value)
+	if err != nil {
+		return append(buf, 0xf6)
+	}
+	return appendCBOR(buf, normalized)
+}
+
+// appendCBORHead appends the initial byte of a data item of the given major type and its argument n in the
+// shortest form
+func appendCBORHead(buf []byte, major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return append(buf, major<<5|byte(n))
+	case n <= math.MaxUint8:
+		return append(buf, major<<5|24, byte(n))
+	case n <= math.MaxUint16:
+		return appendUint(append(buf, major<<5|25), n, 2)
+	case n <= math.MaxUint32:
+		return appendUint(append(buf, major<<5|26), n, 4)
+	}
+	return appendUint(append(buf, major<<5|27), n, 8)
+}
+
+func appendCBORInt(buf []byte, n int64) []byte {
+	if n >= 0 {
+		return appendCBORHead(buf, cborUnsigned, uint64(n))
+	}
+	return appendCBORHead(buf, cborNegative, uint64(-1-n))
+}
+
+// appendCBORBigInt appends n as an integer if it fits into 64 bits and as a bignum (tag 2 or 3) otherwise
+func appendCBORBigInt(buf []byte, n *big.Int) []byte {
+	major, tag := byte(cborUnsigned), uint64(cborTagPositiveBignum)
+	// negative integers are encoded as -1 - n
+	argument := new(big.Int).Set(n)
+	if n.Sign() < 0 {
+		major, tag = cborNegative, cborTagNegativeBignum
+		argument.Neg(argument.Add(argument, big.NewInt(1)))
+	}
+	if argument.IsUint64() {
+		return appendCBORHead(buf, major, argument.Uint64())
+	}
+	buf = appendCBORHead(buf, cborTag, tag)
+	data := argument.Bytes()
+	buf = appendCBORHead(buf, cborBytes, uint64(len(data)))
+	return append(buf, data...)
+}
+
+// appendCBORFloat appends f as a half, single or double precision float, whichever is the shortest that
+// represents f exactly
+func appendCBORFloat(buf []byte, f float64) []byte {
+	if half, ok := float16Bits(f); ok {
+		return appendUint(append(buf, 0xf9), uint64(half), 2)
+	}
+	if float64(float32(f)) == f {
+		return appendUint(append(buf, 0xfa), uint64(math.Float32bits(float32(f))), 4)
+	}
+	return appendUint(append(buf, 0xfb), math.Float64bits(f), 8)
+}
+
+// float16Bits returns the bits of f as a half precision float, if f can be represented exactly
+func float16Bits(f float64) (uint16, bool) {
+	var sign uint16
+	if math.Signbit(f) {
+		sign = 0x8000
+		f = -f
+	}
+	if f == 0 {
+		return sign, true
+	}
+	frac, exp := math.Frexp(f)
+	// f = m * 2^e with m in [1, 2)
+	m, e := 2*frac, exp-1
+	switch {
+	case e > 15:
+		return 0, false
+	case e >= -14:
+		mantissa := (m - 1) * 1024
+		if mantissa != math.Trunc(mantissa) {
+			return 0, false
+		}
+		return sign | uint16(e+15)<<10 | uint16(mantissa), true
+	}
+	// subnormal numbers are multiples of 2^-24
+	mantissa := math.Ldexp(f, 24)
+	if mantissa != math.Trunc(mantissa) {
+		return 0, false
+	}
+	return sign | uint16(mantissa), true
+}
+
+// float16ToFloat64 converts the bits of a half precision float into a float64
+func float16ToFloat64(half uint16) float64 {
+	exp := int(half>>10) & 0x1f
+	mantissa := float64(half & 0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mantissa, -24)
+	case 0x1f:
+		if mantissa == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mantissa+1024, exp-25)
+	}
+	if half&0x8000 != 0 {
+		f = -f
+	}
+	return f
+}
+
+// cborDecoder decodes CBOR into the values used internally to represent JSON
+type cborDecoder struct {
+	binaryDecoder
+}
+
+func (d *cborDecoder) decode(depth int) (any, error) {
+	if depth > maxBinaryDepth {
+		return nil, d.errorf("nesting depth exceeds the limit of %v", maxBinaryDepth)
+	}
+	start := d.offset
+	major, info, n, err := d.readHead()
+	if err != nil {
+		return nil, err
+	}
+	if info == cborIndefinite && major != cborBytes && major != cborText && major != cborArray && major != cborMap {
+		d.offset = start
+		if major == cborSimple {
+			return nil, d.errorf("unexpected break")
+		}
+		return nil, d.errorf("invalid indefinite length for major type %v", major)
+	}
+	switch major {
+	case cborUnsigned:
+		return normalizeNumber(n), nil
+	case cborNegative:
+		negative := new(big.Int).SetUint64(n)
+		return normalizeBigInt(negative.Neg(negative.Add(negative, big.NewInt(1)))), nil
+	case cborBytes:
+		data, err := d.readString(major, info, n)
+		if err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.EncodeToString(data), nil
+	case cborText:
+		data, err := d.readString(major, info, n)
+		if err != nil {
+			return nil, err
+		}
+		if !utf8.Valid(data) {
+			d.offset = start
+			return nil, d.errorf("string contains invalid UTF-8")
+		}
+		return string(data), nil
+	case cborArray:
+		return d.decodeArray(info, n, depth)
+	case cborMap:
+		return d.decodeMap(info, n, depth)
+	case cborTag:
+		return d.decodeTag(n, depth)
+	}
+	return d.decodeSimple(info, n, start)
+}
+
+// readHead reads the initial byte of a data item and its argument
+func (d *cborDecoder) readHead() (byte, byte, uint64, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	major, info := b[0]>>5, b[0]&0x1f
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info <= 27:
+		n, err := d.readUint(1 << (info - 24))
+		return major, info, n, err
+	case info == cborIndefinite:
+		return major, info, 0, nil
+	}
+	d.offset--
+	return 0, 0, 0, d.errorf("invalid additional information %v", info)
+}
+
+// readString reads the content of a byte or text string. Indefinite-length strings are concatenated from
+// their chunks.
+func (d *cborDecoder) readString(major byte, info byte, n uint64) ([]byte, error) {
+	if info != cborIndefinite {
+		if n > uint64(len(d.data)-d.offset) {
+			return nil, d.unexpectedEnd()
+		}
+		return d.read(int(n))
+	}
+	var data []byte
+	for {
+		if d.atBreak() {
+			return data, nil
+		}
+		start := d.offset
+		chunkMajor, chunkInfo, chunkLength, err := d.readHead()
+		if err != nil {
+			return nil, err
+		}
+		if chunkMajor != major || chunkInfo == cborIndefinite {
+			d.offset = start
+			return nil, d.errorf("invalid chunk of indefinite-length string")
+		}
+		chunk, err := d.readString(major, chunkInfo, chunkLength)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, chunk...)
+	}
+}
+
+func (d *cborDecoder) decodeArray(info byte, n uint64, depth int) (any, error) {
+	// every element of a definite-length array takes at least one byte
+	if info != cborIndefinite && n > uint64(len(d.data)-d.offset) {
+		return nil, d.unexpectedEnd()
+	}
+	elements := make([]any, 0, n)
+	for i := uint64(0); info == cborIndefinite || i < n; i++ {
+		if info == cborIndefinite && d.atBreak() {
+			break
+		}
+		element, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+	}
+	return elements, nil
+}
+
+func (d *cborDecoder) decodeMap(info byte, n uint64, depth int) (any, error) {
+	// every member of a definite-length map takes at least two bytes
+	if info != cborIndefinite && n > uint64(len(d.data)-d.offset)/2 {
+		return nil, d.unexpectedEnd()
+	}
+	members := make(map[string]any, n)
+	for i := uint64(0); info == cborIndefinite || i < n; i++ {
+		if info == cborIndefinite && d.atBreak() {
+			break
+		}
+		start := d.offset
+		key, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		keyText, err := d.mapKey(key, start)
+		if err != nil {
+			return nil, err
+		}
+		value, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		members[keyText] = value
+	}
+	return members, nil
+}
+
+// decodeTag decodes the content of a tag. Date/time and bignum tags are converted, other tags are ignored.
+func (d *cborDecoder) decodeTag(tag uint64, depth int) (any, error) {
+	start := d.offset
+	content, err := d.decode(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+	switch tag {
+	case cborTagDateTime:
+		s, ok := content.(string)
+		if !ok {
+			d.offset = start
+			return nil, d.errorf("date/time must be a text string")
+		}
+		if _, err := time.Parse(time.RFC3339Nano, s); err != nil {
+			d.offset = start
+			return nil, d.errorf("invalid date/time '%v'", s)
+		}
+		return s, nil
+	case cborTagEpochTime:
+		var t time.Time
+		switch seconds := content.(type) {
+		case int:
+			t = time.Unix(int64(seconds), 0)
+		case float64:
+			whole, frac := math.Modf(seconds)
+			t = time.Unix(int64(whole), int64(frac*1e9))
+		default:
+			d.offset = start
+			return nil, d.errorf("epoch time must be a number")
+		}
+		return t.UTC().Format(time.RFC3339Nano), nil
+	case cborTagPositiveBignum, cborTagNegativeBignum:
+		s, ok := content.(string)
+		data, err := base64.StdEncoding.DecodeString(s)
+		if !ok || err != nil || d.data[start]>>5 != cborBytes {
+			d.offset = start
+			return nil, d.errorf("bignum must be a byte string")
+		}
+		n := new(big.Int).SetBytes(data)
+		if tag == cborTagNegativeBignum {
+			n.Neg(n.Add(n, big.NewInt(1)))
+		}
+		return normalizeBigInt(n), nil
+	}
+	return content, nil
+}
+
+// decodeSimple decodes simple values and floats
+func (d *cborDecoder) decodeSimple(info byte, n uint64, start int) (any, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		// null and undefined
+		return nil, nil
+	case 25:
+		return d.checkFloat(float16ToFloat64(uint16(n)), start)
+	case 26:
+		return d.checkFloat(float64(math.Float32frombits(uint32(n))), start)
+	case 27:
+		return d.checkFloat(math.Float64frombits(n), start)
+	}
+	d.offset = start
+	return nil, d.errorf("unsupported simple value %v", n)
+}
+
+// atBreak checks if the next byte is the break that ends an indefinite-length item and skips it
+func (d *cborDecoder) atBreak() bool {
+	if d.offset < len(d.data) && d.data[d.offset] == cborBreak {
+		d.offset++
+		return true
+	}
+	return false
+}
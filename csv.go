@@ -0,0 +1,216 @@
+package jogson
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSVOptions configures how ToCSV writes and NewArrayFromCSV reads CSV
+type CSVOptions struct {
+	// Comma is the field delimiter. It defaults to ','. Use '\t' for TSV.
+	Comma rune
+	// Columns are the columns written by ToCSV, in the given order. If empty, the union of the keys of all
+	// objects is written in ascending order.
+	Columns []string
+	// Flatten writes nested objects and arrays as separate columns with dotted headers, e.g. "address.city"
+	// or "tags.0", instead of JSON text. NewArrayFromCSV converts dotted headers back into nested objects, and
+	// objects whose keys are the indices 0 to n-1 into arrays.
+	Flatten bool
+	// Null is the text of null values. It defaults to an empty string.
+	Null string
+	// InferTypes makes NewArrayFromCSV convert values equal to Null into null, "true" and "false" into bools,
+	// numbers into numbers and JSON objects and arrays into objects and arrays. Otherwise, all values are
+	// strings.
+	InferTypes bool
+}
+
+// ToCSV writes an array of objects as a CSV table to w. The first row is a header with the column names and
+// every object is written as a row. Missing keys are written as empty cells. Nested objects and arrays are
+// written as JSON text unless CSVOptions.Flatten is set. If an element is not an object, TypeConversionErr
+// is returned.
+func (a *JsonArray) ToCSV(w io.Writer, opts CSVOptions) error {
+	rows := make([]map[string]any, 0, len(a.elements))
+	for _, element := range a.elements {
+		mapper := getMapperFromField(element)
+		members, ok := toMemberPtrs(mapper.value)
+		if !ok {
+			return createKindConversionErr(mapper.kind, Object)
+		}
+		row := make(map[string]any, len(members))
+		for key, value := range members {
+			var v any
+			if value != nil {
+				v = *value
+			}
+			if opts.Flatten {
+				flattenCSVValue(key, v, row)
+			} else {
+				row[key] = v
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	columns := opts.Columns
+	if len(columns) == 0 {
+		union := make(map[string]bool)
+		for _, row := range rows {
+			for key := range row {
+				union[key] = true
+			}
+		}
+		columns = sortedKeys(union)
+	}
+
+	writer := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		writer.Comma = opts.Comma
+	}
+	err := writer.Write(columns)
+	if err != nil {
+		return err
+	}
+	record := make([]string, len(columns))
+	for _, row := range rows {
+		for i, column := range columns {
+			value, ok := row[column]
+			if !ok {
+				record[i] = ""
+				continue
+			}
+			record[i] = formatText(value, opts.Null)
+		}
+		err = writer.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// NewArrayFromCSV reads a CSV table from r into an array of objects. The first row is the header and its
+// cells are used as the keys of the objects. All rows must have as many cells as the header. Values are
+// strings unless CSVOptions.InferTypes is set. If the CSV is invalid, ParseErr is returned, and if a header
+// is duplicated, InvalidPathErr.
+func NewArrayFromCSV(r io.Reader, opts CSVOptions) (*JsonArray, error) {
+	reader := csv.NewReader(r)
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return EmptyArray(), nil
+	}
+	if err != nil {
+		return &JsonArray{}, newCSVParseError(err)
+	}
+	err = checkCSVHeader(header, opts.Flatten)
+	if err != nil {
+		return &JsonArray{}, err
+	}
+
+	elements := make([]any, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return &JsonArray{}, newCSVParseError(err)
+		}
+		object := make(map[string]any, len(header))
+		for i, column := range header {
+			value := parseCSVValue(record[i], opts)
+			if opts.Flatten {
+				if !setNestedValue(object, strings.Split(column, "."), value) {
+					return &JsonArray{}, createInvalidPathErr(column)
+				}
+			} else {
+				object[column] = value
+			}
+		}
+		if opts.Flatten {
+			elements = append(elements, membersToArrays(object))
+		} else {
+			elements = append(elements, object)
+		}
+	}
+	return newArrayFromSlice(elementsToPtrs(elements)), nil
+}
+
+// newCSVParseError converts an error returned by the CSV reader into a ParseError
+func newCSVParseError(err error) error {
+	var csvErr *csv.ParseError
+	if errors.As(err, &csvErr) {
+		return &ParseError{Line: csvErr.Line, Column: csvErr.Column, Message: csvErr.Err.Error(), Err: err}
+	}
+	return fmt.Errorf("%w: %w", ParseErr, err)
+}
+
+// flattenCSVValue adds value to row. Objects and arrays are added as one column per value with dotted keys.
+func flattenCSVValue(key string, value any, row map[string]any) {
+	if members, ok := toMemberPtrs(value); ok && len(members) > 0 {
+		for k, v := range members {
+			var child any
+			if v != nil {
+				child = *v
+			}
+			flattenCSVValue(key+"."+k, child, row)
+		}
+		return
+	}
+	if elements, ok := toElementPtrs(value); ok && len(elements) > 0 {
+		for i, e := range elements {
+			var child any
+			if e != nil {
+				child = *e
+			}
+			flattenCSVValue(key+"."+strconv.Itoa(i), child, row)
+		}
+		return
+	}
+	row[key] = value
+}
+
+// parseCSVValue converts the text of a cell into a value
+func parseCSVValue(text string, opts CSVOptions) any {
+	if !opts.InferTypes {
+		return text
+	}
+	if text == opts.Null {
+		return nil
+	}
+	if value, ok := parseJSONText(text); ok {
+		return value
+	}
+	return inferType(text)
+}
+
+// checkCSVHeader checks that no header is duplicated, which would silently drop a column, and, if flatten is
+// set, that no dotted header is a prefix of another, e.g. "a" and "a.b", since the value of "a" cannot be both
+// a scalar and an object
+func checkCSVHeader(header []string, flatten bool) error {
+	columns := make(map[string]bool, len(header))
+	for _, column := range header {
+		if columns[column] {
+			return createInvalidPathErr(column)
+		}
+		columns[column] = true
+	}
+	if !flatten {
+		return nil
+	}
+	for _, column := range header {
+		for i := range column {
+			if column[i] == '.' && columns[column[:i]] {
+				return createInvalidPathErr(column)
+			}
+		}
+	}
+	return nil
+}
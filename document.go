@@ -0,0 +1,401 @@
+package jogson
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const defaultIndentUnit = "  "
+
+// Document is an editable JSON or JSONC document. Unlike JsonObject, which parses the document into a map,
+// a Document edits the source text directly: setting or removing a value only changes the text of that
+// value, and whitespace, indentation, key order and comments everywhere else are preserved. This keeps the
+// diffs of automated config changes minimal.
+type Document struct {
+	data      []byte
+	positions Positions
+}
+
+// NewDocument parses a JSON or JSONC document. Comments and trailing commas are allowed.
+func NewDocument(data []byte) (*Document, error) {
+	d := &Document{data: data}
+	err := d.parse()
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// NewDocumentFromFile reads and parses a JSON or JSONC document from the given path. Files compressed with
+// gzip or zlib are decompressed.
+func NewDocumentFromFile(path string) (*Document, error) {
+	file, err := readFile(path, 0)
+	if err != nil {
+		return nil, err
+	}
+	d, err := NewDocument(file)
+	return d, withParseErrorPath(err, path)
+}
+
+// Bytes returns the source of the document including all edits.
+func (d *Document) Bytes() []byte {
+	return d.data
+}
+
+// String returns the source of the document including all edits.
+func (d *Document) String() string {
+	return string(d.data)
+}
+
+// Mapper parses the document into a JsonMapper, which can be used to read values.
+func (d *Document) Mapper() (JsonMapper, error) {
+	return NewMapperFromBytesWithOptions(d.data, d.parseOptions())
+}
+
+// WriteToFile writes the source of the document to the given path. The file is replaced atomically like in
+// JsonObject.WriteToFile. If the file exists, its permissions are kept.
+func (d *Document) WriteToFile(path string) error {
+	return writeFileAtomic(path, d.data, 0)
+}
+
+// Set sets the value at path. If the value exists, only its text is replaced. If it does not exist but its
+// parent does, a new member is added to the end of the parent object, or a new element is appended to the
+// parent array if the last element of path is the length of the array. The new text is indented like its
+// siblings and inserted after the comment at the end of the line of the last sibling. value may be any
+// value accepted by NewMapperFromValue.
+func (d *Document) Set(path Path, value any) error {
+	normalized, err := normalizeValue(value)
+	if err != nil {
+		return err
+	}
+	if span, ok := d.positions.Value(path); ok {
+		text, err := d.formatValue(normalized, d.lineIndent(span.Start.Offset))
+		if err != nil {
+			return err
+		}
+		return d.splice(span.Start.Offset, span.End.Offset, text)
+	}
+	if len(path) == 0 {
+		return createKeyNotFoundErr(path.String())
+	}
+	parentPath := path.Parent()
+	parentSpan, ok := d.positions.Value(parentPath)
+	if !ok {
+		return createKeyNotFoundErr(parentPath.String())
+	}
+	children := d.children(parentPath)
+	var member string
+	switch d.data[parentSpan.Start.Offset] {
+	case '{':
+		keyBytes, err := marshal(path.Last())
+		if err != nil {
+			return err
+		}
+		member = string(keyBytes) + ": "
+	case '[':
+		if path.Last() != strconv.Itoa(len(children)) {
+			i, _ := strconv.Atoi(path.Last())
+			return createIndexOutOfRangeErr(i, len(children))
+		}
+	default:
+		return createKeyNotFoundErr(path.String())
+	}
+
+	if len(children) == 0 {
+		return d.insertIntoEmpty(parentSpan, member, normalized)
+	}
+	last := children[len(children)-1]
+	indent := d.lineIndent(last.start)
+	text, err := d.formatValue(normalized, indent)
+	if err != nil {
+		return err
+	}
+	if d.lineStart(last.start) == d.lineStart(parentSpan.Start.Offset) {
+		// the parent is written in a single line
+		return d.splice(last.end, last.end, ", "+member+text)
+	}
+	// the new member is inserted after the comma and the comment that follow the last member
+	end := last.end
+	comma := d.commaAfter(last.end)
+	if comma >= 0 {
+		end = comma + 1
+	}
+	lineEnd, ok := d.lineEndAfter(end)
+	if !ok {
+		if comma >= 0 {
+			return d.splice(end, end, " "+member+text)
+		}
+		return d.splice(last.end, last.end, ",\n"+indent+member+text)
+	}
+	if comma < 0 {
+		err = d.splice(last.end, last.end, ",")
+		if err != nil {
+			return err
+		}
+		lineEnd++
+	}
+	return d.splice(lineEnd, lineEnd, "\n"+indent+member+text)
+}
+
+// Remove removes the value at path together with its key and the comma that separates it from its
+// siblings. A comment at the end of the line of a value that is written on its own line is removed with it,
+// all other comments are kept.
+func (d *Document) Remove(path Path) error {
+	if len(path) == 0 {
+		return createKeyNotFoundErr(path.String())
+	}
+	if _, ok := d.positions.Value(path); !ok {
+		return createKeyNotFoundErr(path.String())
+	}
+	parentPath := path.Parent()
+	children := d.children(parentPath)
+	pointer := path.String()
+	i := 0
+	for i < len(children) && children[i].pointer != pointer {
+		i++
+	}
+	if len(children) > 1 {
+		return d.removeChild(children, i)
+	}
+	parentSpan, _ := d.positions.Value(parentPath)
+	start, end := parentSpan.Start.Offset+1, parentSpan.End.Offset-1
+	inner := string(d.data[start:children[i].start]) + string(d.data[children[i].end:end])
+	if strings.TrimSpace(strings.Trim(inner, ",")) == "" {
+		return d.splice(start, end, "")
+	}
+	// the parent contains comments, which are kept
+	childEnd := children[i].end
+	trimmed := bytes.TrimLeft(d.data[childEnd:end], " \t\r\n")
+	if len(trimmed) > 0 && trimmed[0] == ',' {
+		childEnd = end - len(trimmed) + 1
+	}
+	return d.splice(children[i].start, childEnd, "")
+}
+
+// removeChild removes children[i], which has siblings, with its comma. If it is written on its own line,
+// the line is removed together with the comment at its end. Comments on other lines and comments of
+// siblings are kept.
+func (d *Document) removeChild(children []documentChild, i int) error {
+	child := children[i]
+	end := child.end
+	comma := d.commaAfter(child.end)
+	if comma >= 0 {
+		end = comma + 1
+	}
+	// the comma that separates the previous sibling from the child, which is removed if the child is last
+	previousComma := -1
+	if comma < 0 && i > 0 {
+		previousComma = d.commaAfter(children[i-1].end)
+	}
+	start := d.lineStart(child.start)
+	if lineEnd, ok := d.lineEndAfter(end); ok && strings.TrimSpace(string(d.data[start:child.start])) == "" {
+		if lineEnd < len(d.data) && d.data[lineEnd] == '\r' {
+			lineEnd++
+		}
+		if lineEnd < len(d.data) {
+			lineEnd++
+		}
+		err := d.splice(start, lineEnd, "")
+		if err != nil || previousComma < 0 {
+			return err
+		}
+		return d.splice(previousComma, previousComma+1, "")
+	}
+	if comma >= 0 {
+		for end < len(d.data) && (d.data[end] == ' ' || d.data[end] == '\t') {
+			end++
+		}
+		return d.splice(child.start, end, "")
+	}
+	if previousComma < 0 {
+		return d.splice(child.start, child.end, "")
+	}
+	return d.splice(previousComma, child.end, "")
+}
+
+// commaAfter returns the offset of the comma that follows offset, skipping whitespace and comments, or -1 if
+// the next token is not a comma
+func (d *Document) commaAfter(offset int) int {
+	for offset < len(d.data) {
+		switch {
+		case d.data[offset] == ',':
+			return offset
+		case d.data[offset] == ' ' || d.data[offset] == '\t' || d.data[offset] == '\r' || d.data[offset] == '\n':
+			offset++
+		case bytes.HasPrefix(d.data[offset:], []byte("//")):
+			end := bytes.IndexByte(d.data[offset:], '\n')
+			if end < 0 {
+				return -1
+			}
+			offset += end
+		case bytes.HasPrefix(d.data[offset:], []byte("/*")):
+			end := bytes.Index(d.data[offset+2:], []byte("*/"))
+			if end < 0 {
+				return -1
+			}
+			offset += end + 4
+		default:
+			return -1
+		}
+	}
+	return -1
+}
+
+// lineEndAfter returns the offset of the line break that ends the line containing offset, if the rest of the
+// line after offset contains only whitespace and comments
+func (d *Document) lineEndAfter(offset int) (int, bool) {
+	for offset < len(d.data) {
+		switch {
+		case d.data[offset] == '\r' || d.data[offset] == '\n':
+			return offset, true
+		case d.data[offset] == ' ' || d.data[offset] == '\t':
+			offset++
+		case bytes.HasPrefix(d.data[offset:], []byte("//")):
+			end := bytes.IndexAny(d.data[offset:], "\r\n")
+			if end < 0 {
+				return len(d.data), true
+			}
+			return offset + end, true
+		case bytes.HasPrefix(d.data[offset:], []byte("/*")):
+			end := bytes.Index(d.data[offset+2:], []byte("*/"))
+			if end < 0 || bytes.ContainsAny(d.data[offset:offset+end+2], "\r\n") {
+				return 0, false
+			}
+			offset += end + 4
+		default:
+			return 0, false
+		}
+	}
+	return len(d.data), true
+}
+
+// documentChild is the source range of a member or element of an object or array
+type documentChild struct {
+	pointer string
+	start   int
+	end     int
+}
+
+// children returns the members or elements of the object or array at path in source order. The range of a
+// member starts at its key.
+func (d *Document) children(path Path) []documentChild {
+	prefix := path.String() + "/"
+	var children []documentChild
+	for pointer, span := range d.positions.values {
+		if !strings.HasPrefix(pointer, prefix) || strings.Contains(pointer[len(prefix):], "/") {
+			continue
+		}
+		start := span.Start.Offset
+		if keySpan, ok := d.positions.keys[pointer]; ok {
+			start = keySpan.Start.Offset
+		}
+		children = append(children, documentChild{pointer: pointer, start: start, end: span.End.Offset})
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].start < children[j].start
+	})
+	return children
+}
+
+// insertIntoEmpty inserts the first member or element into an empty object or array
+func (d *Document) insertIntoEmpty(parentSpan Span, member string, value any) error {
+	start, end := parentSpan.Start.Offset+1, parentSpan.End.Offset-1
+	parentIndent := d.lineIndent(parentSpan.Start.Offset)
+	inner := d.data[start:end]
+	if len(bytes.TrimSpace(inner)) > 0 {
+		// the parent contains comments
+		text, err := d.formatValue(value, parentIndent)
+		if err != nil {
+			return err
+		}
+		return d.splice(start, start, member+text)
+	}
+	if !bytes.Contains(inner, []byte("\n")) {
+		text, err := d.formatValue(value, parentIndent)
+		if err != nil {
+			return err
+		}
+		return d.splice(start, end, member+text)
+	}
+	indent := parentIndent + d.indentUnit()
+	text, err := d.formatValue(value, indent)
+	if err != nil {
+		return err
+	}
+	return d.splice(start, end, "\n"+indent+member+text+"\n"+parentIndent)
+}
+
+// formatValue returns the JSON text of value. Objects and arrays are indented, starting at indent.
+func (d *Document) formatValue(value any, indent string) (string, error) {
+	members, isObject := toMemberPtrs(value)
+	elements, isArray := toElementPtrs(value)
+	var text []byte
+	var err error
+	if (isObject && len(members) > 0) || (isArray && len(elements) > 0) {
+		// jsoniter does not support prefixes
+		text, err = json.MarshalIndent(value, indent, d.indentUnit())
+	} else {
+		text, err = marshal(value)
+	}
+	return string(text), err
+}
+
+// splice replaces the bytes data[start:end] with text and parses the document again. If the result is
+// invalid, the document is not changed.
+func (d *Document) splice(start int, end int, text string) error {
+	data := make([]byte, 0, len(d.data)-(end-start)+len(text))
+	data = append(data, d.data[:start]...)
+	data = append(data, text...)
+	data = append(data, d.data[end:]...)
+	edited := &Document{data: data}
+	err := edited.parse()
+	if err != nil {
+		return err
+	}
+	*d = *edited
+	return nil
+}
+
+func (d *Document) parse() error {
+	p := newParser(d.data, d.parseOptions())
+	_, err := p.parseDocument()
+	if err != nil {
+		return err
+	}
+	d.positions = p.positions()
+	return nil
+}
+
+func (d *Document) parseOptions() ParseOptions {
+	return ParseOptions{RecordPositions: true, AllowComments: true, AllowTrailingCommas: true}
+}
+
+// lineStart returns the offset at which the line containing offset starts
+func (d *Document) lineStart(offset int) int {
+	return bytes.LastIndexByte(d.data[:offset], '\n') + 1
+}
+
+// lineIndent returns the leading whitespace of the line containing offset
+func (d *Document) lineIndent(offset int) string {
+	start := d.lineStart(offset)
+	end := start
+	for end < len(d.data) && (d.data[end] == ' ' || d.data[end] == '\t') {
+		end++
+	}
+	return string(d.data[start:end])
+}
+
+// indentUnit returns the indentation used by the document, which is the leading whitespace of the first
+// indented line
+func (d *Document) indentUnit() string {
+	for _, line := range bytes.Split(d.data, []byte("\n")) {
+		trimmed := bytes.TrimLeft(line, " \t")
+		if len(trimmed) > 0 && len(trimmed) < len(line) {
+			return string(line[:len(line)-len(trimmed)])
+		}
+	}
+	return defaultIndentUnit
+}
@@ -0,0 +1,163 @@
+package jogson
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const defaultEnvSeparator = "__"
+
+// envVarRegex matches "${VAR}" and "${VAR:-default}"
+var envVarRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?}`)
+
+// EnvOptions configures the conversion between environment variables and JsonObject
+type EnvOptions struct {
+	// Separator separates nested keys in variable names, e.g. "APP_DB__HOST" is the key "host" of the object
+	// "db". It defaults to "__".
+	Separator string
+	// InferTypes converts "true" and "false" into bools and numbers into numbers. Otherwise, all values are
+	// strings.
+	InferTypes bool
+	// JSONValues converts values that are JSON objects or arrays, e.g. `["a","b"]`, into objects and arrays
+	JSONValues bool
+	// Environ are the variables in the form "KEY=VALUE". It defaults to os.Environ().
+	Environ []string
+}
+
+// NewObjectFromEnv builds a JsonObject from the environment variables whose names start with prefix, e.g.
+// "APP_". The prefix is removed and the rest of the name is split by EnvOptions.Separator into nested keys,
+// which are lowercased, so that "APP_DB__HOST=localhost" becomes {"db": {"host": "localhost"}}. Objects
+// whose keys are the indices 0 to n-1 are converted into arrays. Variables with empty keys are ignored. If
+// two variables conflict, e.g. "APP_DB" and "APP_DB__HOST", InvalidPathErr is returned.
+func NewObjectFromEnv(prefix string, opts EnvOptions) (*JsonObject, error) {
+	environ := os.Environ()
+	if opts.Environ != nil {
+		environ = append([]string{}, opts.Environ...)
+	}
+	separator := opts.Separator
+	if separator == "" {
+		separator = defaultEnvSeparator
+	}
+	sort.Strings(environ)
+	object := make(map[string]any)
+	for _, variable := range environ {
+		name, text, ok := strings.Cut(variable, "=")
+		if !ok || !strings.HasPrefix(name, prefix) || name == prefix {
+			continue
+		}
+		keys := strings.Split(strings.ToLower(strings.TrimPrefix(name, prefix)), separator)
+		if containsEmptyKey(keys) {
+			continue
+		}
+		var value any = text
+		if jsonValue, ok := parseJSONText(text); ok && opts.JSONValues {
+			value = jsonValue
+		} else if opts.InferTypes {
+			value = inferType(text)
+		}
+		if !setNestedValue(object, keys, value) {
+			return &JsonObject{}, createInvalidPathErr(name)
+		}
+	}
+	return newObjectFromMap(membersToPtrs(membersToArrays(object))), nil
+}
+
+// ToEnv flattens the JsonObject into environment variables in the form "KEY=VALUE", sorted by key, which
+// can be used as exec.Cmd.Env. Nested keys are joined with "__" and uppercased, and prefix is prepended,
+// e.g. {"db": {"host": "localhost"}} becomes "APP_DB__HOST=localhost" with the prefix "APP_". Characters
+// that are not valid in variable names are replaced with '_'. null is written as an empty value and empty
+// objects and arrays as "{}" and "[]".
+func (o *JsonObject) ToEnv(prefix string) []string {
+	return o.ToEnvWithOptions(prefix, EnvOptions{})
+}
+
+// ToEnvWithOptions is like ToEnv, but nested keys are joined with EnvOptions.Separator
+func (o *JsonObject) ToEnvWithOptions(prefix string, opts EnvOptions) []string {
+	separator := opts.Separator
+	if separator == "" {
+		separator = defaultEnvSeparator
+	}
+	var environ []string
+	for key, value := range o.object {
+		environ = addEnvVariables(environ, prefix+envName(key), derefValue(value), separator)
+	}
+	sort.Strings(environ)
+	return environ
+}
+
+// ExpandEnv returns a copy of the JsonObject in which "${VAR}" and "${VAR:-default}" in all string values,
+// including nested ones, are replaced with the value of the environment variable VAR. If VAR is not set,
+// "${VAR}" is replaced with an empty string and "${VAR:-default}" with default, which is also used if VAR
+// is empty. Keys are not expanded. The original JsonObject is not changed.
+func (o *JsonObject) ExpandEnv() *JsonObject {
+	obj := newObjectFromMap(copyMembers(o.object))
+	obj.TransformValues(expandEnvFunc)
+	return obj
+}
+
+// ExpandEnv returns a copy of the JsonArray in which environment variables in all string values are
+// expanded. See JsonObject.ExpandEnv.
+func (a *JsonArray) ExpandEnv() *JsonArray {
+	arr := newArrayFromSlice(copyElements(a.elements))
+	arr.TransformValues(expandEnvFunc)
+	return arr
+}
+
+// expandEnvFunc is the TransformFunc used by ExpandEnv
+func expandEnvFunc(_ Path, value JsonMapper) (JsonMapper, TransformAction) {
+	s, ok := convertMapperToAny(value).(string)
+	if !ok || !strings.Contains(s, "${") {
+		return value, TransformKeep
+	}
+	expanded := envVarRegex.ReplaceAllStringFunc(s, func(match string) string {
+		groups := envVarRegex.FindStringSubmatch(match)
+		if v, ok := os.LookupEnv(groups[1]); ok && (v != "" || !strings.Contains(match, ":-")) {
+			return v
+		}
+		return groups[2]
+	})
+	return newMapper(expanded), TransformReplace
+}
+
+// addEnvVariables appends value to environ. Objects and arrays are appended as one variable per scalar.
+func addEnvVariables(environ []string, name string, value any, separator string) []string {
+	if members, ok := toMemberPtrs(value); ok && len(members) > 0 {
+		for key, member := range members {
+			environ = addEnvVariables(environ, name+separator+envName(key), derefValue(member), separator)
+		}
+		return environ
+	}
+	if elements, ok := toElementPtrs(value); ok && len(elements) > 0 {
+		for i, element := range elements {
+			environ = addEnvVariables(environ, name+separator+strconv.Itoa(i), derefValue(element), separator)
+		}
+		return environ
+	}
+	return append(environ, name+"="+formatText(value, ""))
+}
+
+// envName converts a key into the part of a variable name
+func envName(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+// containsEmptyKey checks if one of keys is empty
+func containsEmptyKey(keys []string) bool {
+	for _, key := range keys {
+		if key == "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,267 @@
+package jogson
+
+import (
+	"math"
+	"math/big"
+)
+
+// EqualOptions controls how Equal and Contains compare JSON values
+type EqualOptions struct {
+	// IgnoreArrayOrder compares arrays as multisets, i.e. [1, 2] is equal to [2, 1]
+	IgnoreArrayOrder bool
+	// FloatTolerance is the maximum absolute difference between two numbers that are regarded as equal
+	FloatTolerance float64
+	// IgnorePaths are JSONPath-like patterns, e.g. "$.meta.timestamp" or "$..id", of values that are
+	// not compared
+	IgnorePaths []string
+}
+
+// Equal checks if a and b are structurally equal. Unlike comparing the results of String(), the order
+// of keys does not matter and numbers are compared by value, so 1 and 1.0 are equal. Two integers are compared
+// exactly, other numbers as float64.
+func Equal(a, b JsonMapper, opts EqualOptions) bool {
+	c := newComparer(opts)
+	return c.equal(Path{}, convertMapperToAny(a), convertMapperToAny(b))
+}
+
+// Contains checks if doc contains subset as a partial match: every key of an object in subset must exist
+// in the corresponding object in doc with a matching value, and every element of an array in subset must
+// match an element of the corresponding array in doc. Unless IgnoreArrayOrder is set, the elements must
+// appear in the same order. Scalars are compared as in Equal.
+func Contains(doc, subset JsonMapper, opts EqualOptions) bool {
+	c := newComparer(opts)
+	return c.contains(Path{}, convertMapperToAny(doc), convertMapperToAny(subset))
+}
+
+// comparer compares JSON values according to EqualOptions
+type comparer struct {
+	opts        EqualOptions
+	ignorePaths []pathPattern
+}
+
+func newComparer(opts EqualOptions) *comparer {
+	c := &comparer{opts: opts}
+	for _, p := range opts.IgnorePaths {
+		c.ignorePaths = append(c.ignorePaths, compilePathPattern(p))
+	}
+	return c
+}
+
+// isIgnored checks if the value at path should not be compared
+func (c *comparer) isIgnored(path Path) bool {
+	for _, p := range c.ignorePaths {
+		if p.matches(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *comparer) equal(path Path, a, b any) bool {
+	if c.isIgnored(path) {
+		return true
+	}
+	if membersA, ok := toMemberPtrs(a); ok {
+		membersB, ok := toMemberPtrs(b)
+		if !ok {
+			return false
+		}
+		for k, v := range membersA {
+			w, found := membersB[k]
+			if !found {
+				if !c.isIgnored(path.AppendKey(k)) {
+					return false
+				}
+				continue
+			}
+			if !c.equal(path.AppendKey(k), derefValue(v), derefValue(w)) {
+				return false
+			}
+		}
+		for k := range membersB {
+			if _, found := membersA[k]; !found && !c.isIgnored(path.AppendKey(k)) {
+				return false
+			}
+		}
+		return true
+	}
+	if elementsA, ok := toElementPtrs(a); ok {
+		elementsB, ok := toElementPtrs(b)
+		if !ok || len(elementsA) != len(elementsB) {
+			return false
+		}
+		if c.opts.IgnoreArrayOrder {
+			return c.matchUnordered(len(elementsA), len(elementsB), func(i, j int) bool {
+				return c.equal(path.AppendIndex(i), derefValue(elementsA[i]), derefValue(elementsB[j]))
+			})
+		}
+		for i := range elementsA {
+			if !c.equal(path.AppendIndex(i), derefValue(elementsA[i]), derefValue(elementsB[i])) {
+				return false
+			}
+		}
+		return true
+	}
+	return c.equalScalars(a, b)
+}
+
+func (c *comparer) contains(path Path, doc, subset any) bool {
+	if c.isIgnored(path) {
+		return true
+	}
+	if subsetMembers, ok := toMemberPtrs(subset); ok {
+		docMembers, ok := toMemberPtrs(doc)
+		if !ok {
+			return false
+		}
+		for k, v := range subsetMembers {
+			w, found := docMembers[k]
+			if !found {
+				if !c.isIgnored(path.AppendKey(k)) {
+					return false
+				}
+				continue
+			}
+			if !c.contains(path.AppendKey(k), derefValue(w), derefValue(v)) {
+				return false
+			}
+		}
+		return true
+	}
+	if subsetElements, ok := toElementPtrs(subset); ok {
+		docElements, ok := toElementPtrs(doc)
+		if !ok {
+			return false
+		}
+		if c.opts.IgnoreArrayOrder {
+			return c.matchUnordered(len(subsetElements), len(docElements), func(i, j int) bool {
+				return c.contains(path.AppendIndex(j), derefValue(docElements[j]), derefValue(subsetElements[i]))
+			})
+		}
+		// the elements of subset must appear in doc in the same order
+		i := 0
+		for j, d := range docElements {
+			if i < len(subsetElements) && c.contains(path.AppendIndex(j), derefValue(d), derefValue(subsetElements[i])) {
+				i++
+			}
+		}
+		return i == len(subsetElements)
+	}
+	return c.equalScalars(doc, subset)
+}
+
+// matchUnordered checks if each of the n elements of one array can be matched with a distinct one of the m
+// elements of another array, where match reports if element i of the first array matches element j of the
+// second. Since an element may match several others, e.g. with FloatTolerance or in Contains, a maximum
+// bipartite matching is searched with augmenting paths.
+func (c *comparer) matchUnordered(n, m int, match func(i, j int) bool) bool {
+	if n > m {
+		return false
+	}
+	candidates := make([][]int, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			if match(i, j) {
+				candidates[i] = append(candidates[i], j)
+			}
+		}
+		if len(candidates[i]) == 0 {
+			return false
+		}
+	}
+	// matchedBy holds the element of the first array that each element of the second array is matched with
+	matchedBy := make([]int, m)
+	for j := range matchedBy {
+		matchedBy[j] = -1
+	}
+	for i := 0; i < n; i++ {
+		if !augmentMatching(i, candidates, matchedBy, make([]bool, m)) {
+			return false
+		}
+	}
+	return true
+}
+
+// augmentMatching tries to match element i, moving previously matched elements to other candidates if needed
+func augmentMatching(i int, candidates [][]int, matchedBy []int, visited []bool) bool {
+	for _, j := range candidates[i] {
+		if visited[j] {
+			continue
+		}
+		visited[j] = true
+		if matchedBy[j] < 0 || augmentMatching(matchedBy[j], candidates, matchedBy, visited) {
+			matchedBy[j] = i
+			return true
+		}
+	}
+	return false
+}
+
+func (c *comparer) equalScalars(a, b any) bool {
+	if x, ok := a.(int); ok {
+		if y, ok := b.(int); ok && x == y {
+			return true
+		}
+	}
+	integerA, isIntegerA := toInteger(a)
+	integerB, isIntegerB := toInteger(b)
+	if isIntegerA && isIntegerB {
+		// integers are compared exactly, since float64 cannot represent all integers above 2^53
+		difference := new(big.Int).Sub(integerA, integerB)
+		if difference.Sign() == 0 {
+			return true
+		}
+		f, _ := new(big.Float).SetInt(difference.Abs(difference)).Float64()
+		return f <= c.opts.FloatTolerance
+	}
+	numberA, isNumberA := toFloat(a)
+	numberB, isNumberB := toFloat(b)
+	if isNumberA || isNumberB {
+		return isNumberA && isNumberB && math.Abs(numberA-numberB) <= c.opts.FloatTolerance
+	}
+	switch v := a.(type) {
+	case nil:
+		return b == nil
+	case bool:
+		w, ok := b.(bool)
+		return ok && v == w
+	case string:
+		w, ok := b.(string)
+		return ok && v == w
+	}
+	return false
+}
+
+// toFloat returns value as float64 if it is a number
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case *big.Int:
+		f, _ := new(big.Float).SetInt(v).Float64()
+		return f, true
+	}
+	return 0, false
+}
+
+// toInteger returns value as big.Int if it is an int or a *big.Int. Floats are not converted, even if they
+// have no fractional part.
+func toInteger(value any) (*big.Int, bool) {
+	switch v := value.(type) {
+	case int:
+		return big.NewInt(int64(v)), true
+	case *big.Int:
+		return v, true
+	}
+	return nil, false
+}
+
+// derefValue returns the value v points to, or nil if v is nil
+func derefValue(v *any) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
@@ -0,0 +1,95 @@
+package jogson
+
+import (
+	"bytes"
+)
+
+// extractMaxDepth is the maximum nesting depth of the JSON found by ExtractJSON
+const extractMaxDepth = 1000
+
+// EmbeddedJSON is a JSON object or array that was found in text by ExtractJSON
+type EmbeddedJSON struct {
+	Mapper JsonMapper
+	// Start and End are the byte offsets of the JSON in the text, so the JSON is text[Start:End]
+	Start int
+	End   int
+}
+
+// ExtractJSON finds and parses every JSON object and array embedded in arbitrary text, e.g. log lines with
+// timestamps and level prefixes, markdown code fences or HTML. The text is scanned from left to right and
+// every '{' or '[' that starts a valid JSON object or array is returned together with its offsets. Objects
+// and arrays nested in a found document are not returned separately. Brackets that do not start valid
+// JSON, e.g. in "[INFO]", are skipped together with their content. Double quotes inside brackets are
+// treated as JSON strings, so brackets in quotes are not matched. JSON nested deeper than 1000 levels is
+// not returned. The text is scanned in linear time.
+func ExtractJSON(text []byte) []EmbeddedJSON {
+	ends := matchBrackets(text)
+	var found []EmbeddedJSON
+	for offset := 0; offset < len(text); {
+		i := bytes.IndexAny(text[offset:], "{[")
+		if i < 0 {
+			break
+		}
+		start := offset + i
+		end, ok := ends[start]
+		if !ok {
+			offset = start + 1
+			continue
+		}
+		p := newParser(text[start:end], ParseOptions{MaxDepth: extractMaxDepth})
+		value, err := p.parseValue(nil)
+		if err != nil || p.offset != end-start {
+			// JSON inside an invalid span is not returned, so every byte is parsed at most once
+			offset = end
+			continue
+		}
+		found = append(found, EmbeddedJSON{Mapper: newMapperFromParsed(value), Start: start, End: end})
+		offset = end
+	}
+	return found
+}
+
+// ExtractJSONFromString finds and parses every JSON object and array embedded in text. See ExtractJSON.
+func ExtractJSONFromString(text string) []EmbeddedJSON {
+	return ExtractJSON([]byte(text))
+}
+
+// matchBrackets returns the offsets of all '{' and '[' in text that have a matching '}' or ']', mapped to
+// the offset after the match. Brackets inside JSON strings are ignored. Strings are only recognized inside
+// brackets, so quotes in the surrounding text do not hide any JSON.
+func matchBrackets(text []byte) map[int]int {
+	ends := make(map[int]int)
+	var open []int
+	inString := false
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if inString {
+			switch c {
+			case '\\':
+				i++
+			case '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = len(open) > 0
+		case '{', '[':
+			open = append(open, i)
+		case '}', ']':
+			if len(open) == 0 {
+				continue
+			}
+			last := open[len(open)-1]
+			if text[last] == '{' && c == '}' || text[last] == '[' && c == ']' {
+				ends[last] = i + 1
+				open = open[:len(open)-1]
+			} else {
+				// none of the open brackets can start valid JSON, as it would contain this bracket
+				open = open[:0]
+			}
+		}
+	}
+	return ends
+}
@@ -0,0 +1,148 @@
+package jogson
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WriteOptions configures how WriteToFile writes files
+type WriteOptions struct {
+	// Pretty writes indented JSON like PrettyString. Otherwise, the JSON is written like String.
+	Pretty bool
+	// Gzip compresses the file with gzip. Files whose path ends with ".gz" are always compressed.
+	Gzip bool
+	// Perm is the permission of new files. It defaults to 0644. Existing files keep their permissions.
+	Perm os.FileMode
+}
+
+// WriteToFile writes the JsonObject as JSON to the given path. The file is replaced atomically: the JSON is
+// written to a temporary file in the same directory, which is then renamed to path, so readers never see a
+// partially written file.
+func (o *JsonObject) WriteToFile(path string, opts WriteOptions) error {
+	return writeJSONFile(path, o.object, opts)
+}
+
+// WriteToFile writes the JsonArray as JSON to the given path. See JsonObject.WriteToFile.
+func (a *JsonArray) WriteToFile(path string, opts WriteOptions) error {
+	return writeJSONFile(path, a.elements, opts)
+}
+
+// WriteToFile writes the JsonMapper underlying value as JSON to the given path. See JsonObject.WriteToFile.
+func (m *JsonMapper) WriteToFile(path string, opts WriteOptions) error {
+	return writeJSONFile(path, m.value, opts)
+}
+
+// writeJSONFile marshals value and writes it atomically to path
+func writeJSONFile(path string, value any, opts WriteOptions) error {
+	var data []byte
+	var err error
+	if opts.Pretty {
+		data, err = marshalIndent(value)
+	} else {
+		data, err = marshal(value)
+	}
+	if err != nil {
+		return err
+	}
+	if opts.Gzip || strings.EqualFold(filepath.Ext(path), ".gz") {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		_, err = writer.Write(data)
+		if err != nil {
+			return err
+		}
+		err = writer.Close()
+		if err != nil {
+			return err
+		}
+		data = buf.Bytes()
+	}
+
+	return writeFileAtomic(path, data, opts.Perm)
+}
+
+// writeFileAtomic writes data to a temporary file in the directory of path and renames it to path. perm is
+// the permission of a new file and defaults to 0644. An existing file keeps its permissions.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if perm == 0 {
+		perm = 0644
+	}
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := file.Name()
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, perm)
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+	}
+	return err
+}
+
+// readFile reads the file at path. Files compressed with gzip or zlib are detected by their magic bytes or
+// their extension (".gz", ".zz" or ".zlib") and decompressed, raw deflate files by the extension
+// ".deflate". If maxSize is set, larger decompressed files are rejected with InputTooLargeErr.
+func readFile(path string, maxSize int) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var reader io.ReadCloser
+	ext := strings.ToLower(filepath.Ext(path))
+	switch {
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}) || ext == ".gz":
+		reader, err = gzip.NewReader(bytes.NewReader(data))
+	case isZlibHeader(data) || ext == ".zz" || ext == ".zlib":
+		reader, err = zlib.NewReader(bytes.NewReader(data))
+	case ext == ".deflate":
+		reader = flate.NewReader(bytes.NewReader(data))
+	default:
+		return data, nil
+	}
+	if err != nil {
+		return nil, &ParseError{Path: path, Message: err.Error(), Err: err}
+	}
+	defer reader.Close()
+
+	var limited io.Reader = reader
+	if maxSize > 0 {
+		limited = io.LimitReader(reader, int64(maxSize)+1)
+	}
+	decompressed, err := io.ReadAll(limited)
+	if err != nil {
+		return nil, &ParseError{Path: path, Message: err.Error(), Err: err}
+	}
+	if maxSize > 0 && len(decompressed) > maxSize {
+		// the rest is not decompressed, so the size of the input is unknown
+		err = createDecompressedTooLargeErr(maxSize)
+		return nil, &ParseError{Path: path, Message: err.Error(), Err: err}
+	}
+	return decompressed, nil
+}
+
+// isZlibHeader checks if data starts with a zlib header with the default window size. The two bytes of the
+// header are a multiple of 31.
+func isZlibHeader(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0x78 && (uint16(data[0])<<8|uint16(data[1]))%31 == 0
+}
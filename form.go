@@ -0,0 +1,131 @@
+package jogson
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// FormNotation is the notation of nested keys in url.Values and form bodies
+type FormNotation int
+
+const (
+	// FormBracketNotation writes nested keys with brackets, e.g. "user[address][city]" or "tags[0]". Empty
+	// brackets, e.g. "tags[]", append to an array.
+	FormBracketNotation FormNotation = iota
+	// FormDotNotation writes nested keys with dots, e.g. "user.address.city" or "tags.0".
+	FormDotNotation
+)
+
+// FormOptions configures the conversion between url.Values and JsonObject
+type FormOptions struct {
+	// Notation is the notation of nested keys. It defaults to FormBracketNotation.
+	Notation FormNotation
+	// InferTypes converts "true" and "false" into bools and numbers into numbers when reading values.
+	// Otherwise, all values are strings.
+	InferTypes bool
+}
+
+// NewObjectFromValues converts url.Values, e.g. the query of a request or a parsed form, into a JsonObject.
+// Nested keys such as "a[b][0]" or, with FormDotNotation, "a.b.0" are converted into nested objects, and
+// objects whose keys are the indices 0 to n-1 into arrays. A key with multiple values is converted into an
+// array. If two keys conflict, e.g. "a" and "a[b]", InvalidPathErr is returned.
+func NewObjectFromValues(values url.Values, opts FormOptions) (*JsonObject, error) {
+	object := make(map[string]any)
+	for _, key := range sortedKeys(values) {
+		keys := parseFormKey(key, opts.Notation)
+		if len(values[key]) > 1 && keys[len(keys)-1] != "" {
+			// the values of repeated keys are collected into an array
+			keys = append(keys, "")
+		}
+		for _, v := range values[key] {
+			var value any = v
+			if opts.InferTypes {
+				value = inferType(v)
+			}
+			if !setNestedValue(object, keys, value) {
+				return &JsonObject{}, createInvalidPathErr(key)
+			}
+		}
+	}
+	return newObjectFromMap(membersToPtrs(membersToArrays(object))), nil
+}
+
+// NewObjectFromForm parses an application/x-www-form-urlencoded body or a URL query string into a
+// JsonObject. See NewObjectFromValues. If the body is not valid, ParseErr is returned.
+func NewObjectFromForm(body string, opts FormOptions) (*JsonObject, error) {
+	values, err := url.ParseQuery(body)
+	if err != nil {
+		return &JsonObject{}, fmt.Errorf("%w: %w", ParseErr, err)
+	}
+	return NewObjectFromValues(values, opts)
+}
+
+// ToValues converts the JsonObject into url.Values. Nested objects and arrays are flattened into keys in the
+// notation of opts, e.g. "a[b][0]". null is written as an empty value and empty objects and arrays are left
+// out.
+func (o *JsonObject) ToValues(opts FormOptions) url.Values {
+	values := make(url.Values)
+	for key, value := range o.object {
+		addFormValues(values, []string{key}, derefValue(value), opts.Notation)
+	}
+	return values
+}
+
+// FormString returns the JsonObject as an application/x-www-form-urlencoded body, sorted by key. See
+// ToValues.
+func (o *JsonObject) FormString(opts FormOptions) string {
+	return o.ToValues(opts).Encode()
+}
+
+// parseFormKey splits a key into its nested keys. Keys that are not valid in bracket notation, e.g. "a[b",
+// are not split.
+func parseFormKey(key string, notation FormNotation) []string {
+	if notation == FormDotNotation {
+		return strings.Split(key, ".")
+	}
+	i := strings.IndexByte(key, '[')
+	if i <= 0 {
+		return []string{key}
+	}
+	keys := []string{key[:i]}
+	rest := key[i:]
+	for rest != "" {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 0 {
+			return []string{key}
+		}
+		keys = append(keys, rest[1:end])
+		rest = rest[end+1:]
+	}
+	return keys
+}
+
+// addFormValues adds value to values. Objects and arrays are added as one value per scalar with nested keys.
+func addFormValues(values url.Values, keys []string, value any, notation FormNotation) {
+	if members, ok := toMemberPtrs(value); ok {
+		for key, member := range members {
+			addFormValues(values, append(keys[:len(keys):len(keys)], key), derefValue(member), notation)
+		}
+		return
+	}
+	if elements, ok := toElementPtrs(value); ok {
+		for i, element := range elements {
+			addFormValues(values, append(keys[:len(keys):len(keys)], strconv.Itoa(i)), derefValue(element), notation)
+		}
+		return
+	}
+	values.Add(formatFormKey(keys, notation), formatText(value, ""))
+}
+
+// formatFormKey joins nested keys in the given notation
+func formatFormKey(keys []string, notation FormNotation) string {
+	if notation == FormDotNotation {
+		return strings.Join(keys, ".")
+	}
+	if len(keys) == 1 {
+		return keys[0]
+	}
+	return keys[0] + "[" + strings.Join(keys[1:], "][") + "]"
+}
@@ -3,6 +3,9 @@ package jogson
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
+	"sort"
 	"strconv"
 	"time"
 	"unicode"
@@ -21,51 +24,14 @@ type jcn[T any] func(data *any, j jsonI) *T
 
 func getMapperFromField(data *any) JsonMapper {
 	if data == nil {
-		return JsonMapper{IsNull: true}
+		return JsonMapper{kind: Null}
 	}
+	return newMapper(*data)
+}
 
-	var mapper JsonMapper
-	switch value := (*data).(type) {
-	case bool:
-		mapper.IsBool = true
-		mapper.AsBool = value
-	case int:
-		mapper.IsInt = true
-		mapper.AsInt = value
-	case float64:
-		if value == float64(int(value)) {
-			mapper.IsInt = true
-			mapper.AsInt = int(value)
-		} else {
-			mapper.IsFloat = true
-		}
-		mapper.AsFloat = value
-	case string:
-		mapper.IsString = true
-		mapper.AsString = value
-	case map[string]any:
-		mapper.IsObject = true
-		mapper.AsObject = convertAnyToObject(data, nil)
-	case []float64:
-		mapper.IsArray = true
-		mapper.AsArray = convertSliceToJsonArray(value)
-	case []int:
-		mapper.IsArray = true
-		mapper.AsArray = convertSliceToJsonArray(value)
-	case []string:
-		mapper.IsArray = true
-		mapper.AsArray = convertSliceToJsonArray(value)
-	case []bool:
-		mapper.IsArray = true
-		mapper.AsArray = convertSliceToJsonArray(value)
-	case []*any:
-		mapper.IsArray = true
-		mapper.AsArray = *newArrayFromSlice(value)
-	case []any:
-		mapper.IsArray = true
-		mapper.AsArray = *newArrayFromSlice(convertToSlicePtr(value))
-	}
-	return mapper
+// convertMapperToAny converts the JsonMapper m back to its underlying value
+func convertMapperToAny(m JsonMapper) any {
+	return m.value
 }
 
 func getGenericMap[T any](f jc[T], o JsonObject) map[string]T {
@@ -190,6 +156,8 @@ func convertAnyToString(data *any, j jsonI) string {
 		return strconv.FormatFloat(v, 'f', -1, 64)
 	case int:
 		return strconv.Itoa(v)
+	case *big.Int:
+		return v.String()
 	case bool:
 		return strconv.FormatBool(v)
 	default:
@@ -211,6 +179,9 @@ func convertAnyToStringN(data *any, j jsonI) *string {
 	case int:
 		i := strconv.Itoa(v)
 		return &i
+	case *big.Int:
+		i := v.String()
+		return &i
 	case bool:
 		f := strconv.FormatBool(v)
 		return &f
@@ -370,6 +341,315 @@ func convertToMapValuesPtr(data map[string]any) map[string]*any {
 	return jsonObject
 }
 
+// toElementPtrs returns the elements of value as []*any if value is any of the supported slice types
+func toElementPtrs(value any) ([]*any, bool) {
+	switch v := value.(type) {
+	case []*any:
+		return v, true
+	case []any:
+		return convertToSlicePtr(v), true
+	case []string:
+		return convertSliceToJsonArray(v).elements, true
+	case []int:
+		return convertSliceToJsonArray(v).elements, true
+	case []float64:
+		return convertSliceToJsonArray(v).elements, true
+	case []bool:
+		return convertSliceToJsonArray(v).elements, true
+	default:
+		return nil, false
+	}
+}
+
+// toMemberPtrs returns the members of value as map[string]*any if value is any of the supported map types
+func toMemberPtrs(value any) (map[string]*any, bool) {
+	switch v := value.(type) {
+	case map[string]*any:
+		return v, true
+	case map[string]any:
+		return convertToMapValuesPtr(v), true
+	default:
+		return nil, false
+	}
+}
+
+// copyValue returns a deep copy of value, so that changes to the copy's objects and arrays
+// do not affect the original
+func copyValue(value any) any {
+	if members, ok := toMemberPtrs(value); ok {
+		return copyMembers(members)
+	}
+	if elements, ok := toElementPtrs(value); ok {
+		return copyElements(elements)
+	}
+	return value
+}
+
+func copyMembers(members map[string]*any) map[string]*any {
+	newMembers := make(map[string]*any, len(members))
+	for k, v := range members {
+		if v == nil {
+			newMembers[k] = nil
+			continue
+		}
+		newValue := copyValue(*v)
+		newMembers[k] = &newValue
+	}
+	return newMembers
+}
+
+func copyElements(elements []*any) []*any {
+	newElements := make([]*any, 0, len(elements))
+	for _, v := range elements {
+		if v == nil {
+			newElements = append(newElements, nil)
+			continue
+		}
+		newValue := copyValue(*v)
+		newElements = append(newElements, &newValue)
+	}
+	return newElements
+}
+
+// normalizeValue converts v into one of the types used internally to represent JSON
+func normalizeValue(v any) (any, error) {
+	switch value := v.(type) {
+	case nil, bool, int, float64, string, []string, []int, []float64, []bool:
+		return value, nil
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32:
+		return normalizeNumber(value), nil
+	case *big.Int:
+		if value == nil {
+			return nil, nil
+		}
+		return normalizeBigInt(value), nil
+	case big.Int:
+		return normalizeBigInt(&value), nil
+	case JsonMapper:
+		return value.value, nil
+	case *JsonMapper:
+		return value.value, nil
+	case JsonObject:
+		return value.object, nil
+	case *JsonObject:
+		return value.object, nil
+	case JsonArray:
+		return value.elements, nil
+	case *JsonArray:
+		return value.elements, nil
+	case map[string]any:
+		members := make(map[string]*any, len(value))
+		for k, element := range value {
+			normalized, err := normalizeValue(element)
+			if err != nil {
+				return nil, err
+			}
+			if normalized == nil {
+				members[k] = nil
+				continue
+			}
+			members[k] = &normalized
+		}
+		return members, nil
+	case []any:
+		elements := make([]*any, 0, len(value))
+		for _, element := range value {
+			normalized, err := normalizeValue(element)
+			if err != nil {
+				return nil, err
+			}
+			if normalized == nil {
+				elements = append(elements, nil)
+				continue
+			}
+			elements = append(elements, &normalized)
+		}
+		return elements, nil
+	case map[string]*any, []*any:
+		return value, nil
+	}
+	// any other value is converted through its JSON representation
+	jsonBytes, err := marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	err = unmarshal(jsonBytes, &decoded)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeValue(decoded)
+}
+
+// normalizeNumber converts any Go number to int, to *big.Int if it is an integer that does not fit into int
+// or to float64 if it is a float
+func normalizeNumber(v any) any {
+	switch n := v.(type) {
+	case int8:
+		return int(n)
+	case int16:
+		return int(n)
+	case int32:
+		return int(n)
+	case int64:
+		if n >= math.MinInt && n <= math.MaxInt {
+			return int(n)
+		}
+		return big.NewInt(n)
+	case uint:
+		if uint64(n) <= math.MaxInt {
+			return int(n)
+		}
+		return new(big.Int).SetUint64(uint64(n))
+	case uint8:
+		return int(n)
+	case uint16:
+		return int(n)
+	case uint32:
+		return int(n)
+	case uint64:
+		if n <= math.MaxInt {
+			return int(n)
+		}
+		return new(big.Int).SetUint64(n)
+	case float32:
+		return float64(n)
+	}
+	return v
+}
+
+// normalizeBigInt returns n as int if it fits into int. Larger integers, e.g. uint64 values of MessagePack or
+// CBOR bignums, are kept as *big.Int, which is a number like int and float64 but keeps all digits.
+func normalizeBigInt(n *big.Int) any {
+	if n.IsInt64() && n.Int64() >= math.MinInt && n.Int64() <= math.MaxInt {
+		return int(n.Int64())
+	}
+	return new(big.Int).Set(n)
+}
+
+// formatText returns value as plain text, e.g. for CSV cells. null is written as the given text and objects
+// and arrays as JSON.
+func formatText(value any, null string) string {
+	switch v := value.(type) {
+	case nil:
+		return null
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case *big.Int:
+		return v.String()
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e21 {
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	jsonBytes, _ := marshal(value)
+	return string(jsonBytes)
+}
+
+// inferType converts "true" and "false" into bools and numbers in JSON syntax into numbers. Other text,
+// including numbers with leading zeros such as zip codes, is returned as it is.
+func inferType(text string) any {
+	switch {
+	case text == "true":
+		return true
+	case text == "false":
+		return false
+	case numberRegex.MatchString(text):
+		if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return normalizeNumber(n)
+		}
+		f, _ := strconv.ParseFloat(text, 64)
+		return f
+	}
+	return text
+}
+
+// parseJSONText parses text that starts with '{' or '[' as a JSON object or array. If text is not a valid
+// JSON object or array, false is returned.
+func parseJSONText(text string) (any, bool) {
+	if !dataStartsWith([]byte(text), '{') && !dataStartsWith([]byte(text), '[') {
+		return nil, false
+	}
+	value, err := newParser([]byte(text), ParseOptions{}).parseDocument()
+	return value, err == nil
+}
+
+// setNestedValue sets value in object at the path given by keys, creating nested objects as needed. An
+// empty key appends to the object, using the number of its members as the key. If a key on the path is a
+// value other than an object, or the value to replace is an object, false is returned.
+func setNestedValue(object map[string]any, keys []string, value any) bool {
+	for i, key := range keys {
+		if key == "" {
+			key = strconv.Itoa(len(object))
+		}
+		existing, exists := object[key]
+		if i == len(keys)-1 {
+			if _, isObject := existing.(map[string]any); isObject {
+				return false
+			}
+			object[key] = value
+			return true
+		}
+		child, isObject := existing.(map[string]any)
+		if !isObject {
+			if exists {
+				return false
+			}
+			child = make(map[string]any)
+			object[key] = child
+		}
+		object = child
+	}
+	return true
+}
+
+// indicesToArrays converts objects whose keys are the indices 0 to n-1 into arrays
+func indicesToArrays(value any) any {
+	object, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+	for key, child := range object {
+		object[key] = indicesToArrays(child)
+	}
+	elements := make([]any, len(object))
+	for i := range elements {
+		element, ok := object[strconv.Itoa(i)]
+		if !ok {
+			return object
+		}
+		elements[i] = element
+	}
+	if len(elements) == 0 {
+		return object
+	}
+	return elements
+}
+
+// membersToArrays converts the members of object with indicesToArrays. Unlike indicesToArrays(object),
+// object itself is kept as an object even if its keys are indices.
+func membersToArrays(object map[string]any) map[string]any {
+	for key, child := range object {
+		object[key] = indicesToArrays(child)
+	}
+	return object
+}
+
+// sortedKeys returns the keys of m in ascending order
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func parseTime(t *any, j jsonI) time.Time {
 	j.setLastError(nil)
 	if t == nil {
@@ -410,6 +690,39 @@ func parseUUID(t *any, j jsonI) uuid.UUID {
 	return uuidValue
 }
 
+func parseBigInt(t *any, j jsonI) *big.Int {
+	j.setLastError(nil)
+	if t == nil {
+		j.setLastError(createTypeConversionErr(nil, &big.Int{}))
+		return nil
+	}
+	n, ok := toBigInt(*t)
+	if !ok {
+		j.setLastError(createTypeConversionErr(*t, &big.Int{}))
+		return nil
+	}
+	return n
+}
+
+// toBigInt converts integers and strings of decimal integers into big.Int
+func toBigInt(v any) (*big.Int, bool) {
+	switch n := v.(type) {
+	case int:
+		return big.NewInt(int64(n)), true
+	case *big.Int:
+		return new(big.Int).Set(n), true
+	case float64:
+		if n != math.Trunc(n) || math.IsInf(n, 0) {
+			return nil, false
+		}
+		i, _ := big.NewFloat(n).Int(nil)
+		return i, true
+	case string:
+		return new(big.Int).SetString(n, 10)
+	}
+	return nil, false
+}
+
 func marshal(v any) ([]byte, error) {
 	jsonBytes, err := jsonIter.Marshal(v)
 	if err != nil {
@@ -447,21 +760,43 @@ func dataStartsWith(data []byte, brackOrParen byte) bool {
 }
 
 func transformKeys(m map[string]*any, f func(string) string) map[string]*any {
-	newMap := make(map[string]*any)
+	newMap := make(map[string]*any, len(m))
 	for key, value := range m {
 		newKey := f(key)
 		if value == nil {
 			newMap[newKey] = nil
 			continue
 		}
-		nestedMap, ok := (*value).(map[string]any)
-		if ok {
-			nestedResult := transformKeys(convertToMapValuesPtr(nestedMap), f)
-			var nestedInterface any = nestedResult
-			newMap[newKey] = &nestedInterface
-		} else {
-			newMap[newKey] = value
-		}
+		nestedResult := transformValueKeys(*value, f)
+		newMap[newKey] = &nestedResult
 	}
 	return newMap
 }
+
+func transformArrayKeys(elements []*any, f func(string) string) []*any {
+	newArray := make([]*any, 0, len(elements))
+	for _, element := range elements {
+		if element == nil {
+			newArray = append(newArray, nil)
+			continue
+		}
+		nestedResult := transformValueKeys(*element, f)
+		newArray = append(newArray, &nestedResult)
+	}
+	return newArray
+}
+
+func transformValueKeys(value any, f func(string) string) any {
+	switch v := value.(type) {
+	case map[string]*any:
+		return transformKeys(v, f)
+	case map[string]any:
+		return transformKeys(convertToMapValuesPtr(v), f)
+	case []*any:
+		return transformArrayKeys(v, f)
+	case []any:
+		return transformArrayKeys(convertToSlicePtr(v), f)
+	default:
+		return value
+	}
+}
@@ -0,0 +1,344 @@
+package jogson
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// INIOptions configures how INI and .properties files are read
+type INIOptions struct {
+	// DuplicateKeys defines how keys that appear more than once in the same section are handled. By default,
+	// the last value is kept.
+	DuplicateKeys DuplicateKeyPolicy
+	// InferTypes converts "true" and "false" into bools and numbers into numbers. Otherwise, all values are
+	// strings. Quoted INI values are always strings.
+	InferTypes bool
+}
+
+// NewObjectFromINI parses an INI file into a JsonObject. Keys before the first section are added to the
+// object itself and sections are converted into nested objects. Dotted section names and keys, e.g.
+// "[database.replica]" or "pool.size = 10", are converted into nested objects, and objects whose keys are
+// the indices 0 to n-1 into arrays. Keys and values are separated by '=' or ':'. Lines that start with ';'
+// or '#' are comments and a line that ends with a backslash is continued on the next line. Values in double
+// quotes can contain the escapes \", \\, \n, \r and \t, other values are used as they are. Sections that
+// appear more than once are merged. If the file is not valid INI, ParseErr is returned.
+func NewObjectFromINI(data []byte, opts INIOptions) (*JsonObject, error) {
+	b := newKeyValueBuilder(data, opts.DuplicateKeys)
+	var section Path
+	offset := 0
+	if bytes.HasPrefix(data, []byte("\ufeff")) {
+		offset = len("\ufeff")
+	}
+	for offset < len(data) {
+		lineStart := offset
+		var line []byte
+		line, offset = readLine(data, offset)
+		indent := len(line) - len(bytes.TrimLeft(line, " \t"))
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			end := bytes.IndexByte(line, ']')
+			if end < 0 {
+				return &JsonObject{}, newParseErrorAt(data, lineStart+indent, "missing ']' after section name", nil)
+			}
+			if rest := bytes.TrimSpace(line[end+1:]); len(rest) > 0 && rest[0] != ';' && rest[0] != '#' {
+				return &JsonObject{}, newParseErrorAt(data, lineStart+indent+end+1, "unexpected text after section name", nil)
+			}
+			keys, ok := splitDottedKey(string(line[1:end]))
+			if !ok {
+				return &JsonObject{}, newParseErrorAt(data, lineStart+indent, "empty section name", nil)
+			}
+			_, err := b.object(keys, lineStart+indent)
+			if err != nil {
+				return &JsonObject{}, err
+			}
+			section = keys
+			continue
+		}
+
+		for bytes.HasSuffix(line, []byte(`\`)) && offset < len(data) {
+			var next []byte
+			next, offset = readLine(data, offset)
+			line = append(line[:len(line)-1:len(line)-1], bytes.TrimSpace(next)...)
+		}
+		separator := bytes.IndexAny(line, "=:")
+		if separator < 0 {
+			return &JsonObject{}, newParseErrorAt(data, lineStart+indent, "missing '=' after key", nil)
+		}
+		keys, ok := splitDottedKey(string(line[:separator]))
+		if !ok {
+			return &JsonObject{}, newParseErrorAt(data, lineStart+indent, "empty key", nil)
+		}
+		value, err := parseINIValue(string(bytes.TrimSpace(line[separator+1:])), opts.InferTypes)
+		if err != nil {
+			return &JsonObject{}, newParseErrorAt(data, lineStart+indent+separator+1, err.Error(), nil)
+		}
+		err = b.set(append(section[:len(section):len(section)], keys...), value, lineStart+indent)
+		if err != nil {
+			return &JsonObject{}, err
+		}
+	}
+	return b.build(), nil
+}
+
+// NewObjectFromINIFile reads an INI file from the given path and parses it into a JsonObject. See
+// NewObjectFromINI.
+func NewObjectFromINIFile(path string, opts INIOptions) (*JsonObject, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return &JsonObject{}, err
+	}
+	obj, err := NewObjectFromINI(file, opts)
+	return obj, withParseErrorPath(err, path)
+}
+
+// INIString returns an INI representation of the JsonObject. Objects are written as sections and all other
+// values as keys before the first section. Nested objects and arrays within sections are written with
+// dotted keys, e.g. "pool.size" or "hosts.0". Keys are sorted, null is written as an empty value and empty
+// objects and arrays within sections are left out. Strings that would otherwise be read as numbers or bools,
+// or that have leading or trailing whitespace, are quoted. If a key cannot be represented in INI, e.g.
+// because it contains a dot, InvalidPathErr is returned.
+func (o *JsonObject) INIString() (string, error) {
+	var global []keyValueEntry
+	var sections []string
+	for _, key := range sortedKeys(o.object) {
+		v := derefValue(o.object[key])
+		if _, isObject := toMemberPtrs(v); isObject {
+			sections = append(sections, key)
+			continue
+		}
+		global = flattenKeyValues(global, Path{key}, v)
+	}
+
+	var sb strings.Builder
+	err := writeINIEntries(&sb, global, 0)
+	if err != nil {
+		return "", err
+	}
+	for _, section := range sections {
+		if !validINIKey(section) {
+			return "", createInvalidPathErr(Path{section}.String())
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("[" + section + "]\n")
+		entries := flattenKeyValues(nil, Path{section}, derefValue(o.object[section]))
+		err = writeINIEntries(&sb, entries, 1)
+		if err != nil {
+			return "", err
+		}
+	}
+	return sb.String(), nil
+}
+
+// parseINIValue converts the text of a value into a value. Quoted values are unescaped.
+func parseINIValue(text string, inferTypes bool) (any, error) {
+	if !strings.HasPrefix(text, `"`) {
+		if inferTypes {
+			return inferType(text), nil
+		}
+		return text, nil
+	}
+	var sb strings.Builder
+	for i := 1; i < len(text); i++ {
+		c := text[i]
+		switch c {
+		case '"':
+			if rest := strings.TrimSpace(text[i+1:]); rest != "" && rest[0] != ';' && rest[0] != '#' {
+				return nil, fmt.Errorf("unexpected text after quoted value")
+			}
+			return sb.String(), nil
+		case '\\':
+			i++
+			if i == len(text) {
+				return nil, fmt.Errorf("missing closing quote")
+			}
+			switch text[i] {
+			case '"', '\\':
+				sb.WriteByte(text[i])
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				return nil, fmt.Errorf("invalid escape '\\%c'", text[i])
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return nil, fmt.Errorf("missing closing quote")
+}
+
+// writeINIEntries writes entries as "key = value" lines. The first skip keys of every entry are the section
+// and are not written.
+func writeINIEntries(sb *strings.Builder, entries []keyValueEntry, skip int) error {
+	for _, entry := range entries {
+		for _, key := range entry.keys[skip:] {
+			if !validINIKey(key) {
+				return createInvalidPathErr(entry.keys.String())
+			}
+		}
+		sb.WriteString(strings.TrimSpace(strings.Join(entry.keys[skip:], ".")+" = "+iniValue(entry.value)) + "\n")
+	}
+	return nil
+}
+
+// iniValue returns the text of a value, quoted if necessary
+func iniValue(value any) string {
+	s, isString := value.(string)
+	if !isString {
+		return formatText(value, "")
+	}
+	if _, isText := inferType(s).(string); isText && s == strings.TrimSpace(s) &&
+		!strings.HasPrefix(s, `"`) && !strings.HasSuffix(s, `\`) && !strings.ContainsAny(s, "\r\n") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// validINIKey checks if key can be written as an INI key or section name
+func validINIKey(key string) bool {
+	return key != "" && key == strings.TrimSpace(key) && key[0] != ';' && key[0] != '#' && key[0] != '[' &&
+		!strings.ContainsAny(key, ".=:[]\r\n")
+}
+
+// keyValueBuilder builds a JsonObject from the dotted keys of INI and .properties files
+type keyValueBuilder struct {
+	data          []byte
+	root          map[string]any
+	duplicateKeys DuplicateKeyPolicy
+	// offsets holds the offsets of the keys that were set for DuplicateKeyError, collected holds the keys
+	// whose values were collected into an array for DuplicateKeyCollect
+	offsets   map[string]int
+	collected map[string]bool
+}
+
+func newKeyValueBuilder(data []byte, duplicateKeys DuplicateKeyPolicy) *keyValueBuilder {
+	return &keyValueBuilder{
+		data:          data,
+		root:          make(map[string]any),
+		duplicateKeys: duplicateKeys,
+		offsets:       make(map[string]int),
+		collected:     make(map[string]bool),
+	}
+}
+
+// object returns the nested object at keys, creating it if needed. If a key on the path is a value other
+// than an object, ParseErr is returned.
+func (b *keyValueBuilder) object(keys []string, offset int) (map[string]any, error) {
+	object := b.root
+	for i, key := range keys {
+		existing, exists := object[key]
+		if !exists {
+			child := make(map[string]any)
+			object[key] = child
+			object = child
+			continue
+		}
+		child, isObject := existing.(map[string]any)
+		if !isObject {
+			message := fmt.Sprintf("'%v' is already defined as a value", strings.Join(keys[:i+1], "."))
+			return nil, newParseErrorAt(b.data, offset, message, nil)
+		}
+		object = child
+	}
+	return object, nil
+}
+
+// set sets the value of the key at keys according to the duplicate key policy
+func (b *keyValueBuilder) set(keys []string, value any, offset int) error {
+	parent, err := b.object(keys[:len(keys)-1], offset)
+	if err != nil {
+		return err
+	}
+	key := keys[len(keys)-1]
+	name := strings.Join(keys, ".")
+	existing, exists := parent[key]
+	if _, isObject := existing.(map[string]any); isObject {
+		return newParseErrorAt(b.data, offset, fmt.Sprintf("'%v' is already defined as an object", name), nil)
+	}
+	if exists {
+		switch b.duplicateKeys {
+		case DuplicateKeyKeepFirst:
+			return nil
+		case DuplicateKeyError:
+			line, column, _ := lineAndColumn(b.data, b.offsets[name])
+			err = createDuplicateKeyErr(name, line, column)
+			return newParseErrorAt(b.data, offset, err.Error(), err)
+		case DuplicateKeyCollect:
+			if b.collected[name] {
+				parent[key] = append(existing.([]any), value)
+			} else {
+				parent[key] = []any{existing, value}
+				b.collected[name] = true
+			}
+			return nil
+		}
+	}
+	parent[key] = value
+	b.offsets[name] = offset
+	return nil
+}
+
+// build returns the JsonObject built so far
+func (b *keyValueBuilder) build() *JsonObject {
+	return newObjectFromMap(membersToPtrs(membersToArrays(b.root)))
+}
+
+// keyValueEntry is a scalar and its nested keys while writing INI and .properties files
+type keyValueEntry struct {
+	keys  Path
+	value any
+}
+
+// flattenKeyValues appends the scalars of value to entries. Objects and arrays are appended as one entry per
+// scalar with nested keys, in ascending order. Empty objects and arrays are left out.
+func flattenKeyValues(entries []keyValueEntry, keys Path, value any) []keyValueEntry {
+	if members, ok := toMemberPtrs(value); ok {
+		for _, key := range sortedKeys(members) {
+			entries = flattenKeyValues(entries, keys.AppendKey(key), derefValue(members[key]))
+		}
+		return entries
+	}
+	if elements, ok := toElementPtrs(value); ok {
+		for i, element := range elements {
+			entries = flattenKeyValues(entries, keys.AppendIndex(i), derefValue(element))
+		}
+		return entries
+	}
+	return append(entries, keyValueEntry{keys: keys, value: value})
+}
+
+// splitDottedKey splits a key into its nested keys. If one of them is empty, false is returned.
+func splitDottedKey(key string) ([]string, bool) {
+	keys := strings.Split(key, ".")
+	for i := range keys {
+		keys[i] = strings.TrimSpace(keys[i])
+		if keys[i] == "" {
+			return nil, false
+		}
+	}
+	return keys, true
+}
+
+// readLine returns the line that starts at offset without its line ending and the offset of the next line
+func readLine(data []byte, offset int) ([]byte, int) {
+	end := bytes.IndexAny(data[offset:], "\r\n")
+	if end < 0 {
+		return data[offset:], len(data)
+	}
+	next := offset + end + 1
+	if data[offset+end] == '\r' && next < len(data) && data[next] == '\n' {
+		next++
+	}
+	return data[offset : offset+end], next
+}
@@ -1,7 +1,8 @@
 package jogson
 
 import (
-	"os"
+	"math/big"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +12,7 @@ import (
 type JsonArray struct {
 	elements  []*any
 	LastError error
+	positions Positions
 }
 
 // NewArrayFromBytes parses JSON data from a byte slice.
@@ -18,18 +20,19 @@ func NewArrayFromBytes(data []byte) (*JsonArray, error) {
 	jsonArray := EmptyArray()
 	err := unmarshal(data, &jsonArray.elements)
 	if err != nil {
-		return &JsonArray{}, err
+		return &JsonArray{}, newParseError(data, &[]any{}, err)
 	}
 	return jsonArray, nil
 }
 
 // NewArrayFromFile reads a JSON file from the given path and parses it into a JsonArray object.
 func NewArrayFromFile(path string) (*JsonArray, error) {
-	file, err := os.ReadFile(path)
+	file, err := readFile(path, 0)
 	if err != nil {
 		return &JsonArray{}, err
 	}
-	return NewArrayFromBytes(file)
+	arr, err := NewArrayFromBytes(file)
+	return arr, withParseErrorPath(err, path)
 }
 
 // NewArrayFromString parses JSON from a string into a JsonArray object.
@@ -249,6 +252,15 @@ func (a *JsonArray) GetUUID(i int) uuid.UUID {
 	return getArrayScalar(a, parseUUID, i)
 }
 
+// GetBigInt retrieves the value as *big.Int at the specified index. The value can be an integer, including
+// integers that do not fit into int such as large MessagePack or CBOR integers, or a string of a decimal
+// integer.
+// If the index is out of range, the value is invalid or null, an error will be set to LastError.
+// In case of an error, nil will be returned.
+func (a *JsonArray) GetBigInt(i int) *big.Int {
+	return getArrayScalar(a, parseBigInt, i)
+}
+
 // GetObject retrieves the JsonObject from the element at the specified index.
 // If the index is out of range, the value is invalid or is null, an error will be set to LastError.
 func (a *JsonArray) GetObject(i int) *JsonObject {
@@ -262,15 +274,18 @@ func (a *JsonArray) GetObject(i int) *JsonObject {
 		a.setLastError(createTypeConversionErr(nil, ""))
 		return nullObject()
 	}
+	var obj *JsonObject
 	switch v := (*element).(type) {
 	case map[string]*any:
-		return newObjectFromMap(v)
+		obj = newObjectFromMap(v)
 	case map[string]any:
-		return newObjectFromMap(convertToMapValuesPtr(v))
+		obj = newObjectFromMap(convertToMapValuesPtr(v))
 	default:
 		a.setLastError(createTypeConversionErr(*element, JsonObject{}))
 		return nullObject()
 	}
+	obj.positions = a.positions.sub(strconv.Itoa(i))
+	return obj
 }
 
 // GetArray retrieves the JsonArray from the element at the specified index.
@@ -286,15 +301,18 @@ func (a *JsonArray) GetArray(i int) *JsonArray {
 		a.setLastError(createTypeConversionErr(nil, JsonArray{}))
 		return EmptyArray()
 	}
+	var arr *JsonArray
 	switch v := (*element).(type) {
 	case []*any:
-		return newArrayFromSlice(v)
+		arr = newArrayFromSlice(v)
 	case []any:
-		return newArrayFromSlice(convertToSlicePtr(v))
+		arr = newArrayFromSlice(convertToSlicePtr(v))
 	default:
 		a.setLastError(createTypeConversionErr(*element, JsonArray{}))
 		return EmptyArray()
 	}
+	arr.positions = a.positions.sub(strconv.Itoa(i))
+	return arr
 }
 
 // AddJsonObject appends a JsonObject to the JsonArray.
@@ -356,6 +374,19 @@ func (a *JsonArray) AddNull() {
 	a.elements = append(a.elements, nil)
 }
 
+// TransformKeys returns a new JsonArray in which the keys of all objects, including nested ones,
+// are transformed. It takes a transformation function as parameter f that takes a string,
+// the original key, and returns a new string, the new key.
+func (a *JsonArray) TransformKeys(f func(string) string) *JsonArray {
+	return newArrayFromSlice(transformArrayKeys(a.elements, f))
+}
+
+// ConvertKeys returns a new JsonArray in which the keys of all objects, including nested ones,
+// are converted with the CaseConverter c.
+func (a *JsonArray) ConvertKeys(c CaseConverter) *JsonArray {
+	return a.TransformKeys(c.Convert)
+}
+
 // ForEach applies the given function to each element in the JsonArray.
 func (a *JsonArray) ForEach(f func(j JsonMapper)) {
 	for _, element := range a.elements {
@@ -402,7 +433,7 @@ func (a *JsonArray) FilterNull() JsonArray {
 	var arr = EmptyArray()
 	for _, element := range a.elements {
 		field := getMapperFromField(element)
-		if !field.IsNull {
+		if !field.IsNull() {
 			arr.elements = append(arr.elements, element)
 		}
 	}
@@ -414,7 +445,7 @@ func (a *JsonArray) FilterNull() JsonArray {
 func (a *JsonArray) All() bool {
 	for _, element := range a.elements {
 		field := getMapperFromField(element)
-		if field.IsNull {
+		if field.IsNull() {
 			return false
 		}
 	}
@@ -429,7 +460,7 @@ func (a *JsonArray) Any() bool {
 	}
 	for _, element := range a.elements {
 		field := getMapperFromField(element)
-		if !field.IsNull {
+		if !field.IsNull() {
 			return true
 		}
 	}
@@ -7,9 +7,23 @@ import (
 
 var (
 	typeConversionErrStr  = "%T could not be converted to %T"
+	kindConversionErrStr  = "%v could not be converted to %v"
 	keyNotFoundErrStr     = "'%v'"
 	indexOutOfRangeErrStr = "[%v] with length %v"
 	invalidTime           = "'%v' could not be parsed as time"
+	invalidPathErrStr     = "'%v'"
+	canonicalizationStr   = "'%v' of type %T cannot be canonicalized"
+	inputTooLargeErrStr   = "input of %v bytes exceeds the limit of %v bytes"
+	decompressedErrStr    = "decompressed input exceeds the limit of %v bytes"
+	maxDepthErrStr        = "nesting depth exceeds the limit of %v"
+	stringTooLongErrStr   = "string of %v bytes exceeds the limit of %v bytes"
+	tooManyElementsErrStr = "more than %v keys or elements"
+	duplicateKeyErrStr    = "'%v', first defined at %v:%v"
+	invalidUTF8ErrStr     = "string contains invalid UTF-8"
+	yamlConversionErrStr  = "line %v, column %v: %v"
+	tomlConversionErrStr  = "'%v': %v"
+	xmlConversionErrStr   = "'%v': %v"
+	binaryParseErrStr     = "%v: offset %v: %v"
 )
 
 var (
@@ -18,12 +32,28 @@ var (
 	IndexOutOfRangeErr    = errors.New("index out of range")
 	TimeTypeConversionErr = errors.New("time conversion error")
 	InvalidTimeErr        = errors.New("invalid time")
+	InvalidPathErr        = errors.New("invalid path")
+	CanonicalizationErr   = errors.New("canonicalization error")
+	ParseErr              = errors.New("parse error")
+	InputTooLargeErr      = errors.New("input too large")
+	MaxDepthExceededErr   = errors.New("maximum depth exceeded")
+	StringTooLongErr      = errors.New("string too long")
+	TooManyElementsErr    = errors.New("too many elements")
+	DuplicateKeyErr       = errors.New("duplicate key")
+	InvalidUTF8Err        = errors.New("invalid UTF-8")
+	YAMLConversionErr     = errors.New("YAML conversion error")
+	TOMLConversionErr     = errors.New("TOML conversion error")
+	XMLConversionErr      = errors.New("XML conversion error")
 )
 
 func createTypeConversionErr(fromType any, toType any) error {
 	return fmt.Errorf("%w: %w", TypeConversionErr, fmt.Errorf(typeConversionErrStr, fromType, toType))
 }
 
+func createKindConversionErr(from any, to any) error {
+	return fmt.Errorf("%w: %w", TypeConversionErr, fmt.Errorf(kindConversionErrStr, from, to))
+}
+
 func createKeyNotFoundErr(key string) error {
 	return fmt.Errorf("%w: %w", KeyNotFoundErr, fmt.Errorf(keyNotFoundErrStr, key))
 }
@@ -35,3 +65,55 @@ func createIndexOutOfRangeErr(i int, length int) error {
 func createNewInvalidTimeErr(v any) error {
 	return fmt.Errorf("%w: %w", InvalidTimeErr, fmt.Errorf(invalidTime, v))
 }
+
+func createInvalidPathErr(path string) error {
+	return fmt.Errorf("%w: %w", InvalidPathErr, fmt.Errorf(invalidPathErrStr, path))
+}
+
+func createCanonicalizationErr(v any) error {
+	return fmt.Errorf("%w: %w", CanonicalizationErr, fmt.Errorf(canonicalizationStr, v, v))
+}
+
+func createInputTooLargeErr(size int, limit int) error {
+	return fmt.Errorf("%w: %w", InputTooLargeErr, fmt.Errorf(inputTooLargeErrStr, size, limit))
+}
+
+func createDecompressedTooLargeErr(limit int) error {
+	return fmt.Errorf("%w: %w", InputTooLargeErr, fmt.Errorf(decompressedErrStr, limit))
+}
+
+func createMaxDepthExceededErr(limit int) error {
+	return fmt.Errorf("%w: %w", MaxDepthExceededErr, fmt.Errorf(maxDepthErrStr, limit))
+}
+
+func createStringTooLongErr(length int, limit int) error {
+	return fmt.Errorf("%w: %w", StringTooLongErr, fmt.Errorf(stringTooLongErrStr, length, limit))
+}
+
+func createTooManyElementsErr(limit int) error {
+	return fmt.Errorf("%w: %w", TooManyElementsErr, fmt.Errorf(tooManyElementsErrStr, limit))
+}
+
+func createDuplicateKeyErr(key string, line int, column int) error {
+	return fmt.Errorf("%w: %w", DuplicateKeyErr, fmt.Errorf(duplicateKeyErrStr, key, line, column))
+}
+
+func createInvalidUTF8Err() error {
+	return fmt.Errorf("%w: %w", InvalidUTF8Err, errors.New(invalidUTF8ErrStr))
+}
+
+func createYAMLConversionErr(line int, column int, message string) error {
+	return fmt.Errorf("%w: %w", YAMLConversionErr, fmt.Errorf(yamlConversionErrStr, line, column, message))
+}
+
+func createTOMLConversionErr(path string, message string) error {
+	return fmt.Errorf("%w: %w", TOMLConversionErr, fmt.Errorf(tomlConversionErrStr, path, message))
+}
+
+func createXMLConversionErr(path string, message string) error {
+	return fmt.Errorf("%w: %w", XMLConversionErr, fmt.Errorf(xmlConversionErrStr, path, message))
+}
+
+func createBinaryParseErr(format string, offset int, message string) error {
+	return fmt.Errorf("%w: %w", ParseErr, fmt.Errorf(binaryParseErrStr, format, offset, message))
+}
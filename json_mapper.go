@@ -1,45 +1,36 @@
 package jogson
 
 import (
-	"fmt"
 	"io"
-	"os"
-	"strconv"
-	"strings"
+	"math"
+	"math/big"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-// JsonMapper represents a generic JSON type. It contains fields for all supported JSON
-// types like bool, int, float, string, object, and array, as well as Go supported types.
+// JsonMapper represents a generic JSON value of any kind: null, bool, number, string, object or array.
+// Use Kind() or one of the IsX() methods to check the kind of the value and the AsX() methods to get it.
 type JsonMapper struct {
-	IsBool   bool
-	IsInt    bool
-	IsFloat  bool
-	IsString bool
-	IsObject bool
-	IsArray  bool
-	IsNull   bool
-
-	AsBool   bool
-	AsInt    int
-	AsFloat  float64
-	AsString string
-	AsObject JsonObject
-	AsArray  JsonArray
+	kind      Kind
+	value     any
+	positions Positions
+	// err is set if the value given to newMapper cannot be represented as JSON. It is returned by the AsX()
+	// methods instead of a plain kind mismatch.
+	err error
 
 	reader io.Reader
 }
 
-// NewMapperFromBytes parses JSON data from a byte slice.
+// NewMapperFromBytes parses JSON data from a byte slice. Any JSON value is accepted, including scalars
+// such as strings or numbers. If data is not valid JSON, an error is returned.
 func NewMapperFromBytes(data []byte) (JsonMapper, error) {
 	if dataStartsWith(data, '[') {
 		arrayBytes, err := NewArrayFromBytes(data)
 		if err != nil {
 			return JsonMapper{}, err
 		}
-		return JsonMapper{IsArray: true, AsArray: *arrayBytes}, nil
+		return newMapper(arrayBytes.elements), nil
 	}
 
 	if dataStartsWith(data, '{') {
@@ -47,42 +38,15 @@ func NewMapperFromBytes(data []byte) (JsonMapper, error) {
 		if err != nil {
 			return JsonMapper{}, err
 		}
-		return JsonMapper{IsObject: true, AsObject: *objBytes}, nil
-	}
-
-	asString := string(data)
-	var mapper JsonMapper
-	// check if value is int
-	i, err := strconv.Atoi(asString)
-	if err == nil {
-		mapper.IsInt = true
-		mapper.AsInt = i
-		return JsonMapper{IsInt: true, AsInt: i}, nil
-	}
-	// check if value is float
-	f, err := strconv.ParseFloat(asString, 64)
-	if err == nil {
-		mapper.IsFloat = true
-		mapper.AsFloat = f
-		return JsonMapper{IsFloat: true, AsFloat: f}, nil
-	}
-	// check if value is bool
-	b, err := strconv.ParseBool(asString)
-	if err == nil {
-		mapper.IsBool = true
-		mapper.AsBool = b
-		return JsonMapper{IsBool: true, AsBool: b}, nil
-	}
-	// check if value is null
-	if asString == "null" {
-		mapper.IsNull = true
-		return JsonMapper{IsNull: true}, nil
-	}
-	// fallback to string if no other type was found
-	asString = strings.Trim(asString, `"`)
-	mapper.IsString = true
-	mapper.AsString = asString
-	return JsonMapper{IsString: true, AsString: asString}, nil
+		return newMapper(objBytes.object), nil
+	}
+
+	var value any
+	err := unmarshal(data, &value)
+	if err != nil {
+		return JsonMapper{}, newParseError(data, new(any), err)
+	}
+	return newMapper(value), nil
 }
 
 // NewMapperFromString parses JSON from a string into a JsonMapper object.
@@ -99,13 +63,26 @@ func NewMapperFromStruct[T any](s T) (JsonMapper, error) {
 	return NewMapperFromBytes(jsonBytes)
 }
 
+// NewMapperFromValue converts an already decoded Go value into a JsonMapper. Supported values are nil, bools,
+// numbers of any Go type, strings, maps with string keys, slices, JsonObject, JsonArray and JsonMapper. Other
+// values, e.g. structs, are converted through their JSON representation. If the value cannot be represented
+// as JSON, an error is returned.
+func NewMapperFromValue(v any) (JsonMapper, error) {
+	value, err := normalizeValue(v)
+	if err != nil {
+		return JsonMapper{}, err
+	}
+	return newMapper(value), nil
+}
+
 // NewMapperFromFile reads a JSON file from the given path and parses it into a JsonMapper object.
 func NewMapperFromFile(path string) (JsonMapper, error) {
-	file, err := os.ReadFile(path)
+	file, err := readFile(path, 0)
 	if err != nil {
 		return JsonMapper{}, err
 	}
-	return NewMapperFromBytes(file)
+	mapper, err := NewMapperFromBytes(file)
+	return mapper, withParseErrorPath(err, path)
 }
 
 //func NewMapperFromBuffer(reader io.Reader) (JsonMapper, error) {
@@ -114,26 +91,158 @@ func NewMapperFromFile(path string) (JsonMapper, error) {
 //	return m, nil
 //}
 
-// AsTime retrieves the value as uuid.UUID. Works only if the JSON value is a string.
+// Kind returns the kind of the JSON value.
+func (m *JsonMapper) Kind() Kind {
+	return m.kind
+}
+
+// TypeName returns the name of the kind of the JSON value, e.g. "number" or "object", which is
+// useful for error messages.
+func (m *JsonMapper) TypeName() string {
+	return m.kind.String()
+}
+
+// IsNull checks if the JSON value is null
+func (m *JsonMapper) IsNull() bool {
+	return m.kind == Null
+}
+
+// IsBool checks if the JSON value is a bool
+func (m *JsonMapper) IsBool() bool {
+	return m.kind == Bool
+}
+
+// IsNumber checks if the JSON value is a number, either an integer or a float
+func (m *JsonMapper) IsNumber() bool {
+	return m.kind == Number
+}
+
+// IsInt checks if the JSON value is a number without a fractional part, e.g. 15 or 15.0
+func (m *JsonMapper) IsInt() bool {
+	f, ok := toFloat(m.value)
+	return ok && f == math.Trunc(f)
+}
+
+// IsFloat checks if the JSON value is a number with a fractional part, e.g. 1.5
+func (m *JsonMapper) IsFloat() bool {
+	f, ok := toFloat(m.value)
+	return ok && f != math.Trunc(f)
+}
+
+// IsString checks if the JSON value is a string
+func (m *JsonMapper) IsString() bool {
+	return m.kind == String
+}
+
+// IsObject checks if the JSON value is an object
+func (m *JsonMapper) IsObject() bool {
+	return m.kind == Object
+}
+
+// IsArray checks if the JSON value is an array
+func (m *JsonMapper) IsArray() bool {
+	return m.kind == Array
+}
+
+// AsBool retrieves the value as bool. If the JSON value is not a bool, TypeConversionErr is returned.
+func (m *JsonMapper) AsBool() (bool, error) {
+	v, ok := m.value.(bool)
+	if !ok {
+		return false, m.conversionErr(Bool)
+	}
+	return v, nil
+}
+
+// AsInt retrieves the value as int. If the JSON value is not a number without a fractional part or
+// does not fit into int, TypeConversionErr is returned.
+func (m *JsonMapper) AsInt() (int, error) {
+	switch v := m.value.(type) {
+	case int:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt || v >= math.MaxInt {
+			return 0, createKindConversionErr(v, "int")
+		}
+		return int(v), nil
+	}
+	return 0, m.conversionErr("int")
+}
+
+// AsFloat retrieves the value as float64. If the JSON value is not a number, TypeConversionErr is returned.
+func (m *JsonMapper) AsFloat() (float64, error) {
+	f, ok := toFloat(m.value)
+	if !ok {
+		return 0, m.conversionErr("float")
+	}
+	return f, nil
+}
+
+// AsString retrieves the value as string. If the JSON value is not a string, TypeConversionErr is returned.
+// To get the JSON representation of any value, use String().
+func (m *JsonMapper) AsString() (string, error) {
+	v, ok := m.value.(string)
+	if !ok {
+		return "", m.conversionErr(String)
+	}
+	return v, nil
+}
+
+// AsObject retrieves the value as JsonObject. If the JSON value is not an object, a null JsonObject
+// and TypeConversionErr are returned.
+func (m *JsonMapper) AsObject() (*JsonObject, error) {
+	members, ok := toMemberPtrs(m.value)
+	if !ok {
+		return nullObject(), m.conversionErr(Object)
+	}
+	obj := newObjectFromMap(members)
+	obj.positions = m.positions
+	return obj, nil
+}
+
+// AsArray retrieves the value as JsonArray. If the JSON value is not an array, a null JsonArray
+// and TypeConversionErr are returned.
+func (m *JsonMapper) AsArray() (*JsonArray, error) {
+	elements, ok := toElementPtrs(m.value)
+	if !ok {
+		return nullArray(), m.conversionErr(Array)
+	}
+	arr := newArrayFromSlice(elements)
+	arr.positions = m.positions
+	return arr, nil
+}
+
+// AsTime retrieves the value as time.Time. Works only if the JSON value is a string.
 func (m *JsonMapper) AsTime() (time.Time, error) {
-	if !m.IsString {
+	s, ok := m.value.(string)
+	if !ok {
 		return time.Time{}, TimeTypeConversionErr
 	}
 	for _, layout := range timeLayouts {
-		parsedTime, err := time.Parse(layout, m.AsString)
+		parsedTime, err := time.Parse(layout, s)
 		if err == nil {
 			return parsedTime, nil
 		}
 	}
-	return time.Time{}, createNewInvalidTimeErr(m.AsString)
+	return time.Time{}, createNewInvalidTimeErr(s)
 }
 
 // AsUUID retrieves the value as uuid.UUID. Works only if the JSON value is a string.
 func (m *JsonMapper) AsUUID() (uuid.UUID, error) {
-	if !m.IsString {
-		return uuid.Nil, nil
+	s, ok := m.value.(string)
+	if !ok {
+		return uuid.Nil, m.conversionErr("uuid.UUID")
+	}
+	return uuid.Parse(s)
+}
+
+// AsBigInt retrieves the value as *big.Int. Works only if the JSON value is an integer, including integers
+// that do not fit into int such as large MessagePack or CBOR integers, or a string of a decimal integer.
+func (m *JsonMapper) AsBigInt() (*big.Int, error) {
+	n, ok := toBigInt(m.value)
+	if !ok {
+		return nil, m.conversionErr("big.Int")
 	}
-	return uuid.Parse(m.AsString)
+	return n, nil
 }
 
 //func (m *JsonMapper) ProcessObjectsWithArgs(numberOfWorkers int, f func(o JsonObject, args ...any), args ...any) error {
@@ -184,37 +293,47 @@ func (m *JsonMapper) AsUUID() (uuid.UUID, error) {
 
 // PrettyString returns a valid, pretty JSON string representation of the JsonMapper underlying value.
 func (m *JsonMapper) PrettyString() string {
-	if m.IsBool {
-		return fmt.Sprintf("%v", m.AsBool)
-	} else if m.IsInt {
-		return fmt.Sprintf("%v", m.AsInt)
-	} else if m.IsFloat {
-		return fmt.Sprintf("%v", m.AsFloat)
-	} else if m.IsString {
-		return fmt.Sprintf("%v", m.AsString)
-	} else if m.IsObject {
-		return m.AsObject.PrettyString()
-	} else if m.IsArray {
-		return m.AsArray.PrettyString()
-	}
-	return ""
+	jsonBytes, _ := marshalIndent(m.value)
+	return string(jsonBytes)
 }
 
 // String returns a string representation JsonMapper type in JSON format.
 func (m *JsonMapper) String() string {
-	switch {
-	case m.IsBool:
-		return fmt.Sprintf("%v", m.AsBool)
-	case m.IsInt:
-		return fmt.Sprintf("%v", m.AsInt)
-	case m.IsFloat:
-		return fmt.Sprintf("%v", m.AsFloat)
-	case m.IsString:
-		return fmt.Sprintf("%v", m.AsString)
-	case m.IsObject:
-		return m.AsObject.String()
-	case m.IsArray:
-		return m.AsArray.String()
-	}
-	return ""
+	jsonBytes, _ := marshal(m.value)
+	return string(jsonBytes)
+}
+
+// newMapper returns a JsonMapper holding value, which must be one of the types used internally to
+// represent JSON. Values of other types are normalized first. If that fails, the JsonMapper is null and its
+// AsX() methods return TypeConversionErr.
+func newMapper(value any) JsonMapper {
+	switch value.(type) {
+	case nil:
+		return JsonMapper{kind: Null}
+	case bool:
+		return JsonMapper{kind: Bool, value: value}
+	case int, float64, *big.Int:
+		return JsonMapper{kind: Number, value: value}
+	case string:
+		return JsonMapper{kind: String, value: value}
+	}
+	if _, ok := toMemberPtrs(value); ok {
+		return JsonMapper{kind: Object, value: value}
+	}
+	if _, ok := toElementPtrs(value); ok {
+		return JsonMapper{kind: Array, value: value}
+	}
+	normalized, err := normalizeValue(value)
+	if err != nil {
+		return JsonMapper{kind: Null, err: createTypeConversionErr(value, JsonMapper{})}
+	}
+	return newMapper(normalized)
+}
+
+// conversionErr returns the error of a failed conversion of the value to the given kind or type
+func (m *JsonMapper) conversionErr(to any) error {
+	if m.err != nil {
+		return m.err
+	}
+	return createKindConversionErr(m.kind, to)
 }
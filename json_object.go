@@ -1,7 +1,7 @@
 package jogson
 
 import (
-	"os"
+	"math/big"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +11,7 @@ import (
 type JsonObject struct {
 	object    map[string]*any
 	LastError error
+	positions Positions
 }
 
 // NewObjectFromBytes parses JSON data from a byte slice.
@@ -18,18 +19,19 @@ func NewObjectFromBytes(data []byte) (*JsonObject, error) {
 	jsonObject := EmptyObject()
 	err := unmarshal(data, &jsonObject.object)
 	if err != nil {
-		return &JsonObject{}, err
+		return &JsonObject{}, newParseError(data, &map[string]any{}, err)
 	}
 	return jsonObject, nil
 }
 
 // NewObjectFromFile reads a JSON file from the given path and parses it into a JsonObject object.
 func NewObjectFromFile(path string) (*JsonObject, error) {
-	file, err := os.ReadFile(path)
+	file, err := readFile(path, 0)
 	if err != nil {
 		return &JsonObject{}, err
 	}
-	return NewObjectFromBytes(file)
+	obj, err := NewObjectFromBytes(file)
+	return obj, withParseErrorPath(err, path)
 }
 
 // NewObjectFromStruct serializes a Go struct into JsonObject.
@@ -255,6 +257,15 @@ func (o *JsonObject) GetUUID(key string) uuid.UUID {
 	return getObjectScalar(o, parseUUID, key)
 }
 
+// GetBigInt retrieves the value as *big.Int associated with the specified key. The value can be an integer,
+// including integers that do not fit into int such as large MessagePack or CBOR integers, or a string of a
+// decimal integer.
+// If the key does not exist, the value is invalid or null, an error will be set to LastError.
+// In case of an error, nil will be returned.
+func (o *JsonObject) GetBigInt(key string) *big.Int {
+	return getObjectScalar(o, parseBigInt, key)
+}
+
 // GetObject retrieves a nested JsonObject associated with the specified key.
 // If the key does not exist, the value is invalid or is null, an error will be set to LastError.
 func (o *JsonObject) GetObject(key string) *JsonObject {
@@ -268,15 +279,18 @@ func (o *JsonObject) GetObject(key string) *JsonObject {
 		o.setLastError(createTypeConversionErr(nil, JsonObject{}))
 		return nullObject()
 	}
+	var obj *JsonObject
 	switch value := (*v).(type) {
 	case map[string]*any:
-		return newObjectFromMap(value)
+		obj = newObjectFromMap(value)
 	case map[string]any:
-		return newObjectFromMap(convertToMapValuesPtr(value))
+		obj = newObjectFromMap(convertToMapValuesPtr(value))
 	default:
 		o.setLastError(createTypeConversionErr(*v, JsonObject{}))
 		return nullObject()
 	}
+	obj.positions = o.positions.sub(key)
+	return obj
 }
 
 // GetArray retrieves an array of JsonArray associated with the specified key.
@@ -292,15 +306,18 @@ func (o *JsonObject) GetArray(key string) *JsonArray {
 		o.setLastError(createTypeConversionErr(nil, JsonArray{}))
 		return nullArray()
 	}
+	var arr *JsonArray
 	switch value := (*v).(type) {
 	case []any:
-		return newArrayFromSlice(convertToSlicePtr(value))
+		arr = newArrayFromSlice(convertToSlicePtr(value))
 	case []*any:
-		return newArrayFromSlice(value)
+		arr = newArrayFromSlice(value)
 	default:
 		o.setLastError(createTypeConversionErr(*v, JsonArray{}))
 		return EmptyArray()
 	}
+	arr.positions = o.positions.sub(key)
+	return arr
 }
 
 // AddJsonObject adds a nested JsonObject to the JsonObject associated with the key.
@@ -364,11 +381,19 @@ func (o *JsonObject) AddNull(key string) {
 
 // TransformKeys returns a new JsonObject with transformed keys. It takes a
 // transformation function as parameter f that takes a string, the original key,
-// and returns a new string, the new key.
+// and returns a new string, the new key. Keys of nested objects are transformed as well,
+// including objects that are elements of nested arrays.
 func (o *JsonObject) TransformKeys(f func(string) string) *JsonObject {
 	return newObjectFromMap(transformKeys(o.object, f))
 }
 
+// ConvertKeys returns a new JsonObject with all keys, including nested ones, converted
+// with the CaseConverter c. For example, to convert snake_case keys to camelCase, use
+// ConvertKeys(NewCaseConverter(SnakeCase, CamelCase)).
+func (o *JsonObject) ConvertKeys(c CaseConverter) *JsonObject {
+	return o.TransformKeys(c.Convert)
+}
+
 // ForEach applies the provided function to each key-value pair in the JsonObject.
 func (o *JsonObject) ForEach(f func(key string, j JsonMapper)) {
 	for k, element := range o.object {
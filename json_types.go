@@ -1,6 +1,7 @@
 package jogson
 
 import (
+	"regexp"
 	"time"
 )
 
@@ -13,6 +14,46 @@ type jsonI interface {
 	setLastError(err error)
 }
 
+// Kind represents the type of a JSON value
+type Kind int
+
+const (
+	// Null is the kind of JSON null
+	Null Kind = iota
+	// Bool is the kind of JSON true and false
+	Bool
+	// Number is the kind of JSON numbers, both integers and floats
+	Number
+	// String is the kind of JSON strings
+	String
+	// Object is the kind of JSON objects
+	Object
+	// Array is the kind of JSON arrays
+	Array
+)
+
+// String returns the name of the kind as used in JSON, e.g. "number" or "object".
+func (k Kind) String() string {
+	switch k {
+	case Null:
+		return "null"
+	case Bool:
+		return "bool"
+	case Number:
+		return "number"
+	case String:
+		return "string"
+	case Object:
+		return "object"
+	case Array:
+		return "array"
+	}
+	return "unknown"
+}
+
+// numberRegex matches numbers in JSON syntax
+var numberRegex = regexp.MustCompile(`^-?(0|[1-9]\d*)(\.\d+)?([eE][+-]?\d+)?$`)
+
 var timeLayouts = []string{
 	time.RFC3339,
 	time.RFC850,
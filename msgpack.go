@@ -0,0 +1,362 @@
+package jogson
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+	"math/big"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	msgPackFormat        = "msgpack"
+	msgPackTimestampType = -1
+)
+
+// NewMapperFromMsgPack decodes a MessagePack value into a JsonMapper. Integers and floats are converted into
+// numbers, binary data into base64 strings and timestamps into RFC 3339 strings, which GetTime understands.
+// Unsigned integers that do not fit into int are kept as numbers with all their digits, which GetBigInt
+// returns. Map keys that are numbers or bools are converted into their string form. If data is not valid
+// MessagePack, contains extension types other than timestamps or values that cannot be represented as JSON,
+// ParseErr is returned.
+func NewMapperFromMsgPack(data []byte) (JsonMapper, error) {
+	d := &msgPackDecoder{binaryDecoder{format: msgPackFormat, data: data}}
+	value, err := d.decode(0)
+	if err != nil {
+		return JsonMapper{}, err
+	}
+	if d.offset < len(d.data) {
+		return JsonMapper{}, d.errorf("unexpected data after the top-level value")
+	}
+	return newMapperFromParsed(value), nil
+}
+
+// NewObjectFromMsgPack decodes a MessagePack map into a JsonObject. See NewMapperFromMsgPack.
+func NewObjectFromMsgPack(data []byte) (*JsonObject, error) {
+	mapper, err := NewMapperFromMsgPack(data)
+	if err != nil {
+		return &JsonObject{}, err
+	}
+	return mapper.AsObject()
+}
+
+// NewArrayFromMsgPack decodes a MessagePack array into a JsonArray. See NewMapperFromMsgPack.
+func NewArrayFromMsgPack(data []byte) (*JsonArray, error) {
+	mapper, err := NewMapperFromMsgPack(data)
+	if err != nil {
+		return &JsonArray{}, err
+	}
+	return mapper.AsArray()
+}
+
+// MsgPackBytes returns the MessagePack encoding of the JsonObject. Keys are sorted, so equal objects have
+// equal encodings.
+func (o *JsonObject) MsgPackBytes() []byte {
+	return appendMsgPack(nil, o.object)
+}
+
+// MsgPackBytes returns the MessagePack encoding of the JsonArray.
+func (a *JsonArray) MsgPackBytes() []byte {
+	return appendMsgPack(nil, a.elements)
+}
+
+// MsgPackBytes returns the MessagePack encoding of the JsonMapper underlying value. Numbers without a
+// fractional part are encoded as integers, other numbers as 64-bit floats. Integers that need more than 64
+// bits, which MessagePack cannot represent, are encoded as floats too.
+func (m *JsonMapper) MsgPackBytes() []byte {
+	return appendMsgPack(nil, m.value)
+}
+
+// appendMsgPack appends the MessagePack encoding of value to buf
+func appendMsgPack(buf []byte, value any) []byte {
+	switch v := value.(type) {
+	case nil:
+		return append(buf, 0xc0)
+	case bool:
+		if v {
+			return append(buf, 0xc3)
+		}
+		return append(buf, 0xc2)
+	case int:
+		return appendMsgPackInt(buf, int64(v))
+	case float64:
+		if v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64 {
+			return appendMsgPackInt(buf, int64(v))
+		}
+		buf = append(buf, 0xcb)
+		return appendUint(buf, math.Float64bits(v), 8)
+	case *big.Int:
+		if v.IsInt64() {
+			return appendMsgPackInt(buf, v.Int64())
+		}
+		if v.IsUint64() {
+			return appendUint(append(buf, 0xcf), v.Uint64(), 8)
+		}
+		// integers beyond 64 bits have no MessagePack format
+		f, _ := toFloat(v)
+		return appendMsgPack(buf, f)
+	case string:
+		buf = appendMsgPackHeader(buf, len(v), 0xa0, 32, 0xd9, 0xda, 0xdb)
+		return append(buf, v...)
+	}
+	if members, ok := toMemberPtrs(value); ok {
+		buf = appendMsgPackHeader(buf, len(members), 0x80, 16, 0, 0xde, 0xdf)
+		for _, key := range sortedKeys(members) {
+			buf = appendMsgPack(buf, key)
+			buf = appendMsgPack(buf, derefValue(members[key]))
+		}
+		return buf
+	}
+	if elements, ok := toElementPtrs(value); ok {
+		buf = appendMsgPackHeader(buf, len(elements), 0x90, 16, 0, 0xdc, 0xdd)
+		for _, element := range elements {
+			buf = appendMsgPack(buf, derefValue(element))
+		}
+		return buf
+	}
+	normalized, err := normalizeValue(value)
+	if err != nil {
+		return append(buf, 0xc0)
+	}
+	return appendMsgPack(buf, normalized)
+}
+
+// appendMsgPackInt appends n in the smallest integer format
+func appendMsgPackInt(buf []byte, n int64) []byte {
+	switch {
+	case n >= 0 && n <= 0x7f:
+		return append(buf, byte(n))
+	case n >= -32 && n < 0:
+		return append(buf, byte(n))
+	case n >= 0 && n <= math.MaxUint8:
+		return append(buf, 0xcc, byte(n))
+	case n >= 0 && n <= math.MaxUint16:
+		return appendUint(append(buf, 0xcd), uint64(n), 2)
+	case n >= 0 && n <= math.MaxUint32:
+		return appendUint(append(buf, 0xce), uint64(n), 4)
+	case n >= 0:
+		return appendUint(append(buf, 0xcf), uint64(n), 8)
+	case n >= math.MinInt8:
+		return append(buf, 0xd0, byte(n))
+	case n >= math.MinInt16:
+		return appendUint(append(buf, 0xd1), uint64(n), 2)
+	case n >= math.MinInt32:
+		return appendUint(append(buf, 0xd2), uint64(n), 4)
+	}
+	return appendUint(append(buf, 0xd3), uint64(n), 8)
+}
+
+// appendMsgPackHeader appends the header of a string, array or map of the given length. fix is the first
+// byte of the fix format, which is used for lengths below fixLimit, and format8, format16 and format32 are
+// the first bytes of the formats with 8, 16 and 32-bit lengths. format8 is 0 if there is no such format.
+func appendMsgPackHeader(buf []byte, length int, fix byte, fixLimit int, format8 byte, format16 byte, format32 byte) []byte {
+	switch {
+	case length < fixLimit:
+		return append(buf, fix|byte(length))
+	case format8 != 0 && length <= math.MaxUint8:
+		return append(buf, format8, byte(length))
+	case length <= math.MaxUint16:
+		return appendUint(append(buf, format16), uint64(length), 2)
+	}
+	return appendUint(append(buf, format32), uint64(length), 4)
+}
+
+// msgPackDecoder decodes MessagePack into the values used internally to represent JSON
+type msgPackDecoder struct {
+	binaryDecoder
+}
+
+func (d *msgPackDecoder) decode(depth int) (any, error) {
+	if depth > maxBinaryDepth {
+		return nil, d.errorf("nesting depth exceeds the limit of %v", maxBinaryDepth)
+	}
+	start := d.offset
+	b, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+	c := b[0]
+	switch {
+	case c <= 0x7f:
+		return int(c), nil
+	case c >= 0xe0:
+		return int(int8(c)), nil
+	case c&0xe0 == 0xa0:
+		return d.decodeString(int(c & 0x1f))
+	case c&0xf0 == 0x90:
+		return d.decodeArray(int(c&0x0f), depth)
+	case c&0xf0 == 0x80:
+		return d.decodeMap(int(c&0x0f), depth)
+	}
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		n, err := d.readUint(1 << (c - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+		return normalizeNumber(n), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		n, err := d.readUint(size)
+		if err != nil {
+			return nil, err
+		}
+		// sign extend
+		shift := 64 - 8*size
+		return normalizeNumber(int64(n<<shift) >> shift), nil
+	case 0xca:
+		n, err := d.readUint(4)
+		if err != nil {
+			return nil, err
+		}
+		return d.checkFloat(float64(math.Float32frombits(uint32(n))), start)
+	case 0xcb:
+		n, err := d.readUint(8)
+		if err != nil {
+			return nil, err
+		}
+		return d.checkFloat(math.Float64frombits(n), start)
+	case 0xd9, 0xda, 0xdb:
+		length, err := d.readLength(1 << (c - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeString(length)
+	case 0xc4, 0xc5, 0xc6:
+		length, err := d.readLength(1 << (c - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		data, err := d.read(length)
+		if err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.EncodeToString(data), nil
+	case 0xdc, 0xdd:
+		length, err := d.readLength(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(length, depth)
+	case 0xde, 0xdf:
+		length, err := d.readLength(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeMap(length, depth)
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return d.decodeExtension(1<<(c-0xd4), start)
+	case 0xc7, 0xc8, 0xc9:
+		length, err := d.readLength(1 << (c - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeExtension(length, start)
+	}
+	d.offset = start
+	return nil, d.errorf("invalid format 0x%02x", c)
+}
+
+func (d *msgPackDecoder) decodeString(length int) (any, error) {
+	start := d.offset
+	data, err := d.read(length)
+	if err != nil {
+		return nil, err
+	}
+	if !utf8.Valid(data) {
+		d.offset = start
+		return nil, d.errorf("string contains invalid UTF-8")
+	}
+	return string(data), nil
+}
+
+func (d *msgPackDecoder) decodeArray(length int, depth int) (any, error) {
+	// every element takes at least one byte
+	if length > len(d.data)-d.offset {
+		return nil, d.unexpectedEnd()
+	}
+	elements := make([]any, 0, length)
+	for i := 0; i < length; i++ {
+		element, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+	}
+	return elements, nil
+}
+
+func (d *msgPackDecoder) decodeMap(length int, depth int) (any, error) {
+	// every member takes at least two bytes
+	if length > (len(d.data)-d.offset)/2 {
+		return nil, d.unexpectedEnd()
+	}
+	members := make(map[string]any, length)
+	for i := 0; i < length; i++ {
+		start := d.offset
+		key, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		keyText, err := d.mapKey(key, start)
+		if err != nil {
+			return nil, err
+		}
+		value, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		members[keyText] = value
+	}
+	return members, nil
+}
+
+// decodeExtension decodes the type and data of an extension. Only timestamps are supported.
+func (d *msgPackDecoder) decodeExtension(length int, start int) (any, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+	extType := int8(b[0])
+	data, err := d.read(length)
+	if err != nil {
+		return nil, err
+	}
+	if extType != msgPackTimestampType {
+		d.offset = start
+		return nil, d.errorf("unsupported extension type %v", extType)
+	}
+	var t time.Time
+	switch length {
+	case 4:
+		t = time.Unix(int64(binary.BigEndian.Uint32(data)), 0)
+	case 8:
+		n := binary.BigEndian.Uint64(data)
+		t = time.Unix(int64(n&0x3ffffffff), int64(n>>34))
+	case 12:
+		t = time.Unix(int64(binary.BigEndian.Uint64(data[4:])), int64(binary.BigEndian.Uint32(data)))
+	default:
+		d.offset = start
+		return nil, d.errorf("invalid timestamp length %v", length)
+	}
+	return t.UTC().Format(time.RFC3339Nano), nil
+}
+
+// readLength reads the length of a string, binary, array, map or extension
+func (d *msgPackDecoder) readLength(size int) (int, error) {
+	n, err := d.readUint(size)
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(d.data)) {
+		return 0, d.unexpectedEnd()
+	}
+	return int(n), nil
+}
@@ -0,0 +1,201 @@
+package jogson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxExcerptWidth is the maximum number of characters of the source line shown in ParseError.Excerpt
+const maxExcerptWidth = 60
+
+// ParseError describes where and why parsing JSON failed. It is returned by all constructors that parse
+// JSON, e.g. NewObjectFromBytes or NewMapperFromFile, and can be checked with errors.Is(err, ParseErr)
+// or errors.As.
+type ParseError struct {
+	// Path is the path of the parsed file. It is empty if the JSON was not read from a file.
+	Path string
+	// Offset is the byte offset of the error in the input
+	Offset int
+	// Line and Column are the 1-based position of the error. Column is counted in characters.
+	Line   int
+	Column int
+	// Token is the token at which the error was found, or an empty string at the end of the input
+	Token string
+	// Excerpt is the source line of the error followed by a line with a caret pointing at the error
+	Excerpt string
+	// Message describes the error
+	Message string
+	// Err is the error returned by the underlying decoder
+	Err error
+}
+
+// Error returns the position and description of the error, e.g. "parse error: config.json:3:5: invalid character
+// '"' after object key:value pair".
+func (e *ParseError) Error() string {
+	var position string
+	if e.Line > 0 {
+		position = fmt.Sprintf("%d:%d", e.Line, e.Column)
+	}
+	if e.Path != "" && position != "" {
+		position = e.Path + ":" + position
+	} else if e.Path != "" {
+		position = e.Path
+	}
+	if position == "" {
+		return fmt.Sprintf("%v: %v", ParseErr, e.Message)
+	}
+	return fmt.Sprintf("%v: %v: %v", ParseErr, position, e.Message)
+}
+
+// Unwrap returns the error returned by the underlying decoder.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ParseErr.
+func (e *ParseError) Is(target error) bool {
+	return target == ParseErr
+}
+
+// newParseError wraps err, returned by decoding data into target, in a ParseError. The position of the
+// error is found by decoding data again with encoding/json, which reports the offsets of errors.
+func newParseError(data []byte, target any, err error) error {
+	var offset int
+	var message string
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	stdErr := json.Unmarshal(data, target)
+	switch {
+	case errors.As(stdErr, &syntaxErr):
+		offset = int(syntaxErr.Offset)
+		if !strings.Contains(syntaxErr.Error(), "end of JSON input") {
+			offset--
+		}
+		message = syntaxErr.Error()
+	case errors.As(stdErr, &typeErr):
+		offset = int(typeErr.Offset) - 1
+		message = fmt.Sprintf("cannot unmarshal %v into %v", typeErr.Value, jsonTypeName(target))
+	default:
+		return err
+	}
+	return newParseErrorAt(data, offset, message, err)
+}
+
+// newParseErrorAt returns a ParseError for an error at the byte offset of data
+func newParseErrorAt(data []byte, offset int, message string, err error) *ParseError {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(data) {
+		offset = len(data)
+	}
+	line, column, lineStart := lineAndColumn(data, offset)
+	return &ParseError{
+		Offset:  offset,
+		Line:    line,
+		Column:  column,
+		Token:   tokenAt(data, offset),
+		Excerpt: excerpt(data, lineStart, offset),
+		Message: message,
+		Err:     err,
+	}
+}
+
+// withParseErrorPath sets the file path of err if it is a ParseError
+func withParseErrorPath(err error, path string) error {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		parseErr.Path = path
+	}
+	return err
+}
+
+// jsonTypeName returns the JSON name of the type target points to
+func jsonTypeName(target any) string {
+	switch target.(type) {
+	case *map[string]any, *map[string]*any:
+		return "object"
+	case *[]any, *[]*any:
+		return "array"
+	}
+	return "value"
+}
+
+// lineAndColumn returns the 1-based line and column of offset and the offset at which its line starts
+func lineAndColumn(data []byte, offset int) (int, int, int) {
+	line := 1 + bytes.Count(data[:offset], []byte("\n"))
+	lineStart := bytes.LastIndexByte(data[:offset], '\n') + 1
+	column := 1 + utf8.RuneCount(data[lineStart:offset])
+	return line, column, lineStart
+}
+
+// tokenAt returns the token that starts at offset
+func tokenAt(data []byte, offset int) string {
+	if offset >= len(data) {
+		return ""
+	}
+	switch data[offset] {
+	case '{', '}', '[', ']', ',', ':':
+		return string(data[offset])
+	case '"':
+		end := offset + 1
+		for end < len(data) && data[end] != '"' && data[end] != '\n' {
+			if data[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end < len(data) && data[end] == '"' {
+			end++
+		}
+		if end > len(data) {
+			end = len(data)
+		}
+		return string(data[offset:end])
+	}
+	end := offset
+	for end < len(data) && !bytes.ContainsRune([]byte(" \t\r\n{}[],:\""), rune(data[end])) {
+		end++
+	}
+	if end == offset {
+		_, size := utf8.DecodeRune(data[offset:])
+		end += size
+	}
+	return string(data[offset:end])
+}
+
+// excerpt returns the line starting at lineStart and a caret pointing at offset. Long lines are shortened
+// around offset.
+func excerpt(data []byte, lineStart int, offset int) string {
+	lineEnd := bytes.IndexByte(data[lineStart:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(data)
+	} else {
+		lineEnd += lineStart
+	}
+	line := []rune(strings.TrimRight(string(data[lineStart:lineEnd]), "\r"))
+	column := utf8.RuneCount(data[lineStart:offset])
+	start := 0
+	if column > maxExcerptWidth/2 {
+		start = column - maxExcerptWidth/2
+	}
+	end := start + maxExcerptWidth
+	if end > len(line) {
+		end = len(line)
+	}
+	var sb strings.Builder
+	for _, r := range line[start:end] {
+		if r == '\t' {
+			r = ' '
+		}
+		sb.WriteRune(r)
+	}
+	sb.WriteByte('\n')
+	sb.WriteString(strings.Repeat(" ", column-start))
+	sb.WriteByte('^')
+	return sb.String()
+}
@@ -0,0 +1,962 @@
+package jogson
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ParseOptions controls how JSON is parsed by the WithOptions constructors, e.g.
+// NewObjectFromBytesWithOptions. The zero value parses standard JSON just like NewObjectFromBytes.
+type ParseOptions struct {
+	// RecordPositions records the source span of every key and value, which can be retrieved with
+	// Position or Positions, e.g. to point at the exact location of an invalid value.
+	RecordPositions bool
+	// AllowComments accepts // line comments and /* block comments */, e.g. in JSONC files
+	AllowComments bool
+	// AllowTrailingCommas accepts a comma after the last member of an object or element of an array
+	AllowTrailingCommas bool
+	// JSON5 accepts JSON5 (https://json5.org): comments, trailing commas, single-quoted and multi-line
+	// strings, unquoted keys, hexadecimal numbers, leading and trailing decimal points, explicit plus signs,
+	// Infinity and NaN. Note that Infinity and NaN cannot be written back to JSON.
+	JSON5 bool
+
+	// MaxInputSize is the maximum size of the input in bytes. Larger inputs are rejected with InputTooLargeErr
+	// before they are parsed.
+	MaxInputSize int
+	// MaxDepth is the maximum nesting depth of objects and arrays. Deeper documents are rejected with
+	// MaxDepthExceededErr. It defaults to 10000, the limit of the default decoder, which keeps the
+	// recursive parser from exhausting the stack.
+	MaxDepth int
+	// MaxStringLength is the maximum length of a decoded string or key in bytes. Longer strings are
+	// rejected with StringTooLongErr.
+	MaxStringLength int
+	// MaxElements is the maximum number of keys of an object or elements of an array. Larger objects and
+	// arrays are rejected with TooManyElementsErr.
+	MaxElements int
+	// DuplicateKeys defines how keys that appear more than once in an object are handled. By default,
+	// the last value is kept.
+	DuplicateKeys DuplicateKeyPolicy
+	// RejectInvalidUTF8 rejects strings that are not valid UTF-8 with InvalidUTF8Err. By default, invalid
+	// bytes are kept as they are.
+	RejectInvalidUTF8 bool
+}
+
+// defaultMaxDepth is the nesting depth limit if ParseOptions.MaxDepth is not set
+const defaultMaxDepth = 10000
+
+// DuplicateKeyPolicy defines how the parser handles keys that appear more than once in the same object
+type DuplicateKeyPolicy int
+
+const (
+	// DuplicateKeyKeepLast keeps the last value of a duplicate key, as the default decoder does
+	DuplicateKeyKeepLast DuplicateKeyPolicy = iota
+	// DuplicateKeyKeepFirst keeps the first value of a duplicate key and ignores all others
+	DuplicateKeyKeepFirst
+	// DuplicateKeyError rejects the document with DuplicateKeyErr, reporting the key and the positions of
+	// its first and its duplicate occurrence
+	DuplicateKeyError
+	// DuplicateKeyCollect collects all values of a duplicate key into an array in source order
+	DuplicateKeyCollect
+)
+
+// NewObjectFromBytesWithOptions parses JSON data from a byte slice into a JsonObject according to opts.
+func NewObjectFromBytesWithOptions(data []byte, opts ParseOptions) (*JsonObject, error) {
+	p := newParser(data, opts)
+	value, err := p.parseDocument()
+	if err != nil {
+		return &JsonObject{}, err
+	}
+	members, ok := value.(map[string]any)
+	if !ok {
+		return &JsonObject{}, p.kindError(value, Object)
+	}
+	obj := newObjectFromMap(membersToPtrs(members))
+	obj.positions = p.positions()
+	return obj, nil
+}
+
+// NewObjectFromStringWithOptions parses JSON from a string into a JsonObject according to opts.
+func NewObjectFromStringWithOptions(data string, opts ParseOptions) (*JsonObject, error) {
+	return NewObjectFromBytesWithOptions([]byte(data), opts)
+}
+
+// NewObjectFromFileWithOptions reads a JSON file from the given path and parses it into a JsonObject
+// according to opts.
+func NewObjectFromFileWithOptions(path string, opts ParseOptions) (*JsonObject, error) {
+	file, err := readFileWithOptions(path, opts)
+	if err != nil {
+		return &JsonObject{}, err
+	}
+	obj, err := NewObjectFromBytesWithOptions(file, opts)
+	return obj, withParseErrorPath(err, path)
+}
+
+// NewArrayFromBytesWithOptions parses JSON data from a byte slice into a JsonArray according to opts.
+func NewArrayFromBytesWithOptions(data []byte, opts ParseOptions) (*JsonArray, error) {
+	p := newParser(data, opts)
+	value, err := p.parseDocument()
+	if err != nil {
+		return &JsonArray{}, err
+	}
+	elements, ok := value.([]any)
+	if !ok {
+		return &JsonArray{}, p.kindError(value, Array)
+	}
+	arr := newArrayFromSlice(elementsToPtrs(elements))
+	arr.positions = p.positions()
+	return arr, nil
+}
+
+// NewArrayFromStringWithOptions parses JSON from a string into a JsonArray according to opts.
+func NewArrayFromStringWithOptions(data string, opts ParseOptions) (*JsonArray, error) {
+	return NewArrayFromBytesWithOptions([]byte(data), opts)
+}
+
+// NewArrayFromFileWithOptions reads a JSON file from the given path and parses it into a JsonArray
+// according to opts.
+func NewArrayFromFileWithOptions(path string, opts ParseOptions) (*JsonArray, error) {
+	file, err := readFileWithOptions(path, opts)
+	if err != nil {
+		return &JsonArray{}, err
+	}
+	arr, err := NewArrayFromBytesWithOptions(file, opts)
+	return arr, withParseErrorPath(err, path)
+}
+
+// NewMapperFromBytesWithOptions parses JSON data from a byte slice into a JsonMapper according to opts.
+func NewMapperFromBytesWithOptions(data []byte, opts ParseOptions) (JsonMapper, error) {
+	p := newParser(data, opts)
+	value, err := p.parseDocument()
+	if err != nil {
+		return JsonMapper{}, err
+	}
+	mapper := newMapperFromParsed(value)
+	mapper.positions = p.positions()
+	return mapper, nil
+}
+
+// NewMapperFromStringWithOptions parses JSON from a string into a JsonMapper according to opts.
+func NewMapperFromStringWithOptions(data string, opts ParseOptions) (JsonMapper, error) {
+	return NewMapperFromBytesWithOptions([]byte(data), opts)
+}
+
+// NewMapperFromFileWithOptions reads a JSON file from the given path and parses it into a JsonMapper
+// according to opts.
+func NewMapperFromFileWithOptions(path string, opts ParseOptions) (JsonMapper, error) {
+	file, err := readFileWithOptions(path, opts)
+	if err != nil {
+		return JsonMapper{}, err
+	}
+	mapper, err := NewMapperFromBytesWithOptions(file, opts)
+	return mapper, withParseErrorPath(err, path)
+}
+
+// readFileWithOptions reads the file at path and decompresses it if needed. If opts.MaxInputSize is set,
+// larger files are rejected without reading them.
+func readFileWithOptions(path string, opts ParseOptions) ([]byte, error) {
+	if opts.MaxInputSize > 0 {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if info.Size() > int64(opts.MaxInputSize) {
+			err = createInputTooLargeErr(int(info.Size()), opts.MaxInputSize)
+			return nil, &ParseError{Path: path, Message: err.Error(), Err: err}
+		}
+	}
+	return readFile(path, opts.MaxInputSize)
+}
+
+// parser is a recursive descent JSON parser. Objects and arrays are decoded into map[string]any and
+// []any, numbers into float64, just as the default decoder does.
+type parser struct {
+	data      []byte
+	offset    int
+	opts      ParseOptions
+	recorder  *positionRecorder
+	rootStart int
+	depth     int
+}
+
+func newParser(data []byte, opts ParseOptions) *parser {
+	p := &parser{data: data, opts: opts}
+	if opts.RecordPositions {
+		p.recorder = newPositionRecorder(data)
+	}
+	return p
+}
+
+// positions returns the recorded positions
+func (p *parser) positions() Positions {
+	if p.recorder == nil {
+		return Positions{}
+	}
+	return p.recorder.positions
+}
+
+func (p *parser) allowComments() bool {
+	return p.opts.AllowComments || p.opts.JSON5
+}
+
+func (p *parser) allowTrailingCommas() bool {
+	return p.opts.AllowTrailingCommas || p.opts.JSON5
+}
+
+// parseDocument parses a single JSON value that may be surrounded by whitespace
+func (p *parser) parseDocument() (any, error) {
+	if p.opts.MaxInputSize > 0 && len(p.data) > p.opts.MaxInputSize {
+		return nil, p.limitError(p.opts.MaxInputSize, createInputTooLargeErr(len(p.data), p.opts.MaxInputSize))
+	}
+	err := p.skipWhitespace()
+	if err != nil {
+		return nil, err
+	}
+	p.rootStart = p.offset
+	value, err := p.parseValue(Path{})
+	if err != nil {
+		return nil, err
+	}
+	err = p.skipWhitespace()
+	if err != nil {
+		return nil, err
+	}
+	if p.offset < len(p.data) {
+		return nil, p.errorf("invalid character %v after top-level value", p.currentChar())
+	}
+	return value, nil
+}
+
+func (p *parser) parseValue(path Path) (any, error) {
+	if p.offset >= len(p.data) {
+		return nil, p.unexpectedEnd()
+	}
+	start := p.offset
+	var value any
+	var err error
+	switch c := p.data[p.offset]; {
+	case c == '{':
+		value, err = p.parseObject(path)
+	case c == '[':
+		value, err = p.parseArray(path)
+	case c == '"' || (c == '\'' && p.opts.JSON5):
+		value, err = p.parseString()
+	case c == '-' || isDigit(c):
+		value, err = p.parseNumber()
+	case (c == '+' || c == '.' || c == 'I' || c == 'N') && p.opts.JSON5:
+		value, err = p.parseNumber()
+	case c == 't':
+		value, err = p.parseLiteral("true", true)
+	case c == 'f':
+		value, err = p.parseLiteral("false", false)
+	case c == 'n':
+		value, err = p.parseLiteral("null", nil)
+	default:
+		return nil, p.errorf("invalid character %v looking for beginning of value", p.currentChar())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if p.recorder != nil {
+		p.recorder.recordValue(path, start, p.offset)
+	}
+	return value, nil
+}
+
+func (p *parser) parseObject(path Path) (any, error) {
+	err := p.enterContainer()
+	if err != nil {
+		return nil, err
+	}
+	defer p.leaveContainer()
+	p.offset++
+	members := make(map[string]any)
+	// keyOffsets holds the offsets of the keys for DuplicateKeyError, collected holds the keys whose
+	// values were collected into an array for DuplicateKeyCollect
+	var keyOffsets map[string]int
+	var collected map[string]bool
+	err = p.skipWhitespace()
+	if err != nil {
+		return nil, err
+	}
+	if p.offset < len(p.data) && p.data[p.offset] == '}' {
+		p.offset++
+		return members, nil
+	}
+	for {
+		if p.offset >= len(p.data) {
+			return nil, p.unexpectedEnd()
+		}
+		keyStart := p.offset
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+		keyEnd := p.offset
+		_, isDuplicate := members[key]
+		if p.opts.DuplicateKeys == DuplicateKeyError {
+			if isDuplicate {
+				line, column, _ := lineAndColumn(p.data, keyOffsets[key])
+				return nil, p.limitError(keyStart, createDuplicateKeyErr(key, line, column))
+			}
+			if keyOffsets == nil {
+				keyOffsets = make(map[string]int)
+			}
+			keyOffsets[key] = keyStart
+		}
+		if p.opts.MaxElements > 0 && len(members) >= p.opts.MaxElements && !isDuplicate {
+			return nil, p.limitError(keyStart, createTooManyElementsErr(p.opts.MaxElements))
+		}
+		err = p.skipWhitespace()
+		if err != nil {
+			return nil, err
+		}
+		if p.offset >= len(p.data) {
+			return nil, p.unexpectedEnd()
+		}
+		if p.data[p.offset] != ':' {
+			return nil, p.errorf("invalid character %v after object key", p.currentChar())
+		}
+		p.offset++
+		err = p.skipWhitespace()
+		if err != nil {
+			return nil, err
+		}
+		if isDuplicate && p.opts.DuplicateKeys == DuplicateKeyKeepFirst {
+			// the value is parsed but neither stored nor recorded
+			recorder := p.recorder
+			p.recorder = nil
+			_, err = p.parseValue(nil)
+			p.recorder = recorder
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			childPath := p.childPath(path, key)
+			value, err := p.parseValue(childPath)
+			if err != nil {
+				return nil, err
+			}
+			if p.recorder != nil {
+				p.recorder.recordKey(childPath, keyStart, keyEnd)
+			}
+			switch {
+			case isDuplicate && p.opts.DuplicateKeys == DuplicateKeyCollect:
+				if collected == nil {
+					collected = make(map[string]bool)
+				}
+				if !collected[key] {
+					members[key] = []any{members[key]}
+					collected[key] = true
+				}
+				members[key] = append(members[key].([]any), value)
+			default:
+				members[key] = value
+			}
+		}
+		closed, err := p.parseSeparator('}', "after object key:value pair")
+		if err != nil || closed {
+			return members, err
+		}
+	}
+}
+
+func (p *parser) parseArray(path Path) (any, error) {
+	err := p.enterContainer()
+	if err != nil {
+		return nil, err
+	}
+	defer p.leaveContainer()
+	p.offset++
+	elements := make([]any, 0)
+	err = p.skipWhitespace()
+	if err != nil {
+		return nil, err
+	}
+	if p.offset < len(p.data) && p.data[p.offset] == ']' {
+		p.offset++
+		return elements, nil
+	}
+	for {
+		if p.opts.MaxElements > 0 && len(elements) >= p.opts.MaxElements {
+			return nil, p.limitError(p.offset, createTooManyElementsErr(p.opts.MaxElements))
+		}
+		value, err := p.parseValue(p.childPath(path, strconv.Itoa(len(elements))))
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, value)
+		closed, err := p.parseSeparator(']', "after array element")
+		if err != nil || closed {
+			return elements, err
+		}
+	}
+}
+
+// enterContainer increases the nesting depth when an object or array starts
+func (p *parser) enterContainer() error {
+	p.depth++
+	maxDepth := p.opts.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultMaxDepth
+	}
+	if p.depth > maxDepth {
+		return p.limitError(p.offset, createMaxDepthExceededErr(maxDepth))
+	}
+	return nil
+}
+
+func (p *parser) leaveContainer() {
+	p.depth--
+}
+
+// parseSeparator parses the comma or closing bracket after a member or element and the whitespace
+// after it. It returns true if the object or array was closed.
+func (p *parser) parseSeparator(closing byte, context string) (bool, error) {
+	err := p.skipWhitespace()
+	if err != nil {
+		return false, err
+	}
+	if p.offset >= len(p.data) {
+		return false, p.unexpectedEnd()
+	}
+	switch p.data[p.offset] {
+	case ',':
+		p.offset++
+	case closing:
+		p.offset++
+		return true, nil
+	default:
+		return false, p.errorf("invalid character %v %v", p.currentChar(), context)
+	}
+	err = p.skipWhitespace()
+	if err != nil {
+		return false, err
+	}
+	if p.allowTrailingCommas() && p.offset < len(p.data) && p.data[p.offset] == closing {
+		p.offset++
+		return true, nil
+	}
+	return false, nil
+}
+
+// parseKey parses an object key. In JSON5 mode, keys may also be single-quoted or unquoted identifiers.
+func (p *parser) parseKey() (string, error) {
+	c := p.data[p.offset]
+	if c == '"' || (c == '\'' && p.opts.JSON5) {
+		return p.parseString()
+	}
+	if p.opts.JSON5 {
+		r, size := utf8.DecodeRune(p.data[p.offset:])
+		if isIdentifierStart(r) {
+			start := p.offset
+			p.offset += size
+			for p.offset < len(p.data) {
+				r, size = utf8.DecodeRune(p.data[p.offset:])
+				if !isIdentifierPart(r) {
+					break
+				}
+				p.offset += size
+			}
+			return string(p.data[start:p.offset]), nil
+		}
+	}
+	return "", p.errorf("invalid character %v looking for beginning of object key string", p.currentChar())
+}
+
+// parseString parses a string starting at the opening quote and checks it against the limits
+func (p *parser) parseString() (string, error) {
+	start := p.offset
+	s, err := p.parseStringLiteral()
+	if err != nil {
+		return "", err
+	}
+	if p.opts.MaxStringLength > 0 && len(s) > p.opts.MaxStringLength {
+		return "", p.limitError(start, createStringTooLongErr(len(s), p.opts.MaxStringLength))
+	}
+	return s, nil
+}
+
+// parseStringLiteral parses a string starting at the opening quote
+func (p *parser) parseStringLiteral() (string, error) {
+	quote := p.data[p.offset]
+	p.offset++
+	start := p.offset
+	// fast path for strings without escape sequences
+	for p.offset < len(p.data) {
+		c := p.data[p.offset]
+		if c == quote {
+			s := string(p.data[start:p.offset])
+			p.offset++
+			return s, nil
+		}
+		if c == '\\' {
+			break
+		}
+		if p.isInvalidStringChar(c) {
+			return "", p.errorf("invalid character %v in string literal", p.currentChar())
+		}
+		if c >= utf8.RuneSelf && p.opts.RejectInvalidUTF8 {
+			err := p.checkUTF8()
+			if err != nil {
+				return "", err
+			}
+			continue
+		}
+		p.offset++
+	}
+	buf := make([]byte, 0, p.offset-start+16)
+	buf = append(buf, p.data[start:p.offset]...)
+	for p.offset < len(p.data) {
+		c := p.data[p.offset]
+		switch {
+		case c == quote:
+			p.offset++
+			return string(buf), nil
+		case c == '\\':
+			p.offset++
+			if p.offset >= len(p.data) {
+				return "", p.unexpectedEnd()
+			}
+			var err error
+			buf, err = p.appendEscape(buf)
+			if err != nil {
+				return "", err
+			}
+		case p.isInvalidStringChar(c):
+			return "", p.errorf("invalid character %v in string literal", p.currentChar())
+		case c >= utf8.RuneSelf && p.opts.RejectInvalidUTF8:
+			offset := p.offset
+			err := p.checkUTF8()
+			if err != nil {
+				return "", err
+			}
+			buf = append(buf, p.data[offset:p.offset]...)
+		default:
+			buf = append(buf, c)
+			p.offset++
+		}
+	}
+	return "", p.unexpectedEnd()
+}
+
+// checkUTF8 checks that the character at the current offset is valid UTF-8 and skips it
+func (p *parser) checkUTF8() error {
+	r, size := utf8.DecodeRune(p.data[p.offset:])
+	if r == utf8.RuneError && size == 1 {
+		return p.limitError(p.offset, createInvalidUTF8Err())
+	}
+	p.offset += size
+	return nil
+}
+
+// isInvalidStringChar checks if c must be escaped in a string. JSON5 allows all characters but line
+// terminators.
+func (p *parser) isInvalidStringChar(c byte) bool {
+	if p.opts.JSON5 {
+		return c == '\n' || c == '\r'
+	}
+	return c < 0x20
+}
+
+// appendEscape decodes the escape sequence after a backslash and appends it to buf
+func (p *parser) appendEscape(buf []byte) ([]byte, error) {
+	c := p.data[p.offset]
+	p.offset++
+	switch c {
+	case '"', '\\', '/':
+		return append(buf, c), nil
+	case 'b':
+		return append(buf, '\b'), nil
+	case 'f':
+		return append(buf, '\f'), nil
+	case 'n':
+		return append(buf, '\n'), nil
+	case 'r':
+		return append(buf, '\r'), nil
+	case 't':
+		return append(buf, '\t'), nil
+	case 'u':
+		r, err := p.parseHex(4)
+		if err != nil {
+			return nil, err
+		}
+		if utf16.IsSurrogate(r) {
+			// a surrogate pair is encoded as two consecutive escape sequences
+			if p.offset+1 < len(p.data) && p.data[p.offset] == '\\' && p.data[p.offset+1] == 'u' {
+				offset := p.offset
+				p.offset += 2
+				r2, err := p.parseHex(4)
+				if err != nil {
+					return nil, err
+				}
+				if decoded := utf16.DecodeRune(r, r2); decoded != utf8.RuneError {
+					return utf8.AppendRune(buf, decoded), nil
+				}
+				p.offset = offset
+			}
+			r = utf8.RuneError
+		}
+		return utf8.AppendRune(buf, r), nil
+	}
+	if p.opts.JSON5 {
+		return p.appendJSON5Escape(buf, c)
+	}
+	p.offset--
+	return nil, p.errorf("invalid character %v in string escape code", p.currentChar())
+}
+
+// appendJSON5Escape decodes the escape sequences that JSON5 adds to JSON. c is the character after the
+// backslash.
+func (p *parser) appendJSON5Escape(buf []byte, c byte) ([]byte, error) {
+	switch c {
+	case 'v':
+		return append(buf, '\v'), nil
+	case '0':
+		if p.offset < len(p.data) && isDigit(p.data[p.offset]) {
+			return nil, p.errorf("invalid character %v in string escape code", p.currentChar())
+		}
+		return append(buf, 0), nil
+	case 'x':
+		r, err := p.parseHex(2)
+		if err != nil {
+			return nil, err
+		}
+		return utf8.AppendRune(buf, r), nil
+	case '\r':
+		// a backslash before a line terminator continues the string on the next line
+		if p.offset < len(p.data) && p.data[p.offset] == '\n' {
+			p.offset++
+		}
+		return buf, nil
+	case '\n':
+		return buf, nil
+	}
+	p.offset--
+	r, size := utf8.DecodeRune(p.data[p.offset:])
+	if isDigit(c) {
+		return nil, p.errorf("invalid character %v in string escape code", p.currentChar())
+	}
+	p.offset += size
+	if r == '\u2028' || r == '\u2029' {
+		return buf, nil
+	}
+	// any other character is escaped as itself, e.g. \' or \q
+	return utf8.AppendRune(buf, r), nil
+}
+
+// parseHex parses the n hex digits of a \u or \x escape sequence
+func (p *parser) parseHex(n int) (rune, error) {
+	var r rune
+	for i := 0; i < n; i++ {
+		if p.offset >= len(p.data) {
+			return 0, p.unexpectedEnd()
+		}
+		digit, ok := hexDigit(p.data[p.offset])
+		if !ok {
+			return 0, p.errorf("invalid character %v in \\u hexadecimal character escape", p.currentChar())
+		}
+		r = r*16 + rune(digit)
+		p.offset++
+	}
+	return r, nil
+}
+
+func (p *parser) parseNumber() (any, error) {
+	start := p.offset
+	if p.data[p.offset] == '-' || (p.data[p.offset] == '+' && p.opts.JSON5) {
+		p.offset++
+	}
+	if p.opts.JSON5 {
+		if f, ok, err := p.parseJSON5Number(start); ok {
+			return f, err
+		}
+	}
+	if p.offset >= len(p.data) {
+		return nil, p.unexpectedEnd()
+	}
+	switch c := p.data[p.offset]; {
+	case c == '0':
+		p.offset++
+	case isDigit(c):
+		p.skipDigits()
+	case c == '.' && p.opts.JSON5:
+		// JSON5 allows numbers with a leading decimal point, e.g. .5
+	default:
+		return nil, p.errorf("invalid character %v in numeric literal", p.currentChar())
+	}
+	if p.offset < len(p.data) && p.data[p.offset] == '.' {
+		p.offset++
+		hasIntegerPart := p.offset-1 > start && isDigit(p.data[p.offset-2])
+		if p.offset < len(p.data) && isDigit(p.data[p.offset]) {
+			p.skipDigits()
+		} else if !p.opts.JSON5 || !hasIntegerPart {
+			// JSON5 allows numbers with a trailing decimal point, e.g. 5.
+			if p.offset >= len(p.data) {
+				return nil, p.unexpectedEnd()
+			}
+			return nil, p.errorf("invalid character %v after decimal point in numeric literal", p.currentChar())
+		}
+	}
+	if p.offset < len(p.data) && (p.data[p.offset] == 'e' || p.data[p.offset] == 'E') {
+		p.offset++
+		if p.offset < len(p.data) && (p.data[p.offset] == '+' || p.data[p.offset] == '-') {
+			p.offset++
+		}
+		if p.offset >= len(p.data) {
+			return nil, p.unexpectedEnd()
+		}
+		if !isDigit(p.data[p.offset]) {
+			return nil, p.errorf("invalid character %v in exponent of numeric literal", p.currentChar())
+		}
+		p.skipDigits()
+	}
+	f, err := strconv.ParseFloat(string(p.data[start:p.offset]), 64)
+	if err != nil {
+		return nil, newParseErrorAt(p.data, start, fmt.Sprintf("number %s out of range", p.data[start:p.offset]), err)
+	}
+	return f, nil
+}
+
+// parseJSON5Number parses Infinity, NaN and hexadecimal numbers after the optional sign. If the number
+// is none of these, false is returned.
+func (p *parser) parseJSON5Number(start int) (float64, bool, error) {
+	negative := p.data[start] == '-'
+	rest := p.data[p.offset:]
+	switch {
+	case bytes.HasPrefix(rest, []byte("Infinity")):
+		p.offset += len("Infinity")
+		if negative {
+			return math.Inf(-1), true, nil
+		}
+		return math.Inf(1), true, nil
+	case bytes.HasPrefix(rest, []byte("NaN")):
+		p.offset += len("NaN")
+		return math.NaN(), true, nil
+	case len(rest) >= 2 && rest[0] == '0' && (rest[1] == 'x' || rest[1] == 'X'):
+		p.offset += 2
+		digitsStart := p.offset
+		for p.offset < len(p.data) {
+			if _, ok := hexDigit(p.data[p.offset]); !ok {
+				break
+			}
+			p.offset++
+		}
+		if p.offset == digitsStart {
+			if p.offset >= len(p.data) {
+				return 0, true, p.unexpectedEnd()
+			}
+			return 0, true, p.errorf("invalid character %v in hexadecimal numeric literal", p.currentChar())
+		}
+		n, err := strconv.ParseUint(string(p.data[digitsStart:p.offset]), 16, 64)
+		if err != nil {
+			return 0, true, newParseErrorAt(p.data, start, fmt.Sprintf("number %s out of range", p.data[start:p.offset]), err)
+		}
+		if negative {
+			return -float64(n), true, nil
+		}
+		return float64(n), true, nil
+	case p.offset > start && len(rest) > 0 && !isDigit(rest[0]) && rest[0] != '.':
+		return 0, true, p.errorf("invalid character %v in numeric literal", p.currentChar())
+	}
+	return 0, false, nil
+}
+
+func (p *parser) parseLiteral(literal string, value any) (any, error) {
+	for i := 0; i < len(literal); i++ {
+		if p.offset >= len(p.data) {
+			return nil, p.unexpectedEnd()
+		}
+		if p.data[p.offset] != literal[i] {
+			return nil, p.errorf("invalid character %v in literal %v (expecting %v)", p.currentChar(), literal, quoteChar(rune(literal[i])))
+		}
+		p.offset++
+	}
+	return value, nil
+}
+
+func (p *parser) skipDigits() {
+	for p.offset < len(p.data) && isDigit(p.data[p.offset]) {
+		p.offset++
+	}
+}
+
+// skipWhitespace skips whitespace and, if allowed, comments. JSON5 also allows Unicode whitespace.
+func (p *parser) skipWhitespace() error {
+	for p.offset < len(p.data) {
+		switch c := p.data[p.offset]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			p.offset++
+		case c == '/' && p.allowComments():
+			err := p.skipComment()
+			if err != nil {
+				return err
+			}
+		case c >= utf8.RuneSelf && p.opts.JSON5:
+			r, size := utf8.DecodeRune(p.data[p.offset:])
+			if !unicode.IsSpace(r) && r != '\uFEFF' {
+				return nil
+			}
+			p.offset += size
+		case (c == '\v' || c == '\f') && p.opts.JSON5:
+			p.offset++
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// skipComment skips a // line comment or a /* block comment */
+func (p *parser) skipComment() error {
+	if p.offset+1 >= len(p.data) {
+		return p.errorf("invalid character %v looking for beginning of value", p.currentChar())
+	}
+	switch p.data[p.offset+1] {
+	case '/':
+		end := bytes.IndexByte(p.data[p.offset:], '\n')
+		if end < 0 {
+			p.offset = len(p.data)
+		} else {
+			p.offset += end + 1
+		}
+	case '*':
+		end := bytes.Index(p.data[p.offset+2:], []byte("*/"))
+		if end < 0 {
+			p.offset = len(p.data)
+			return newParseErrorAt(p.data, len(p.data), "unexpected end of JSON input in comment", nil)
+		}
+		p.offset += end + 4
+	default:
+		return p.errorf("invalid character %v looking for beginning of value", p.currentChar())
+	}
+	return nil
+}
+
+// childPath returns the path of a child value. Paths are only needed if positions are recorded.
+func (p *parser) childPath(path Path, key string) Path {
+	if p.recorder == nil {
+		return nil
+	}
+	return path.AppendKey(key)
+}
+
+// currentChar returns the quoted character at the current offset for error messages
+func (p *parser) currentChar() string {
+	r, _ := utf8.DecodeRune(p.data[p.offset:])
+	return quoteChar(r)
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return newParseErrorAt(p.data, p.offset, fmt.Sprintf(format, args...), nil)
+}
+
+// limitError returns a ParseError at offset that wraps err
+func (p *parser) limitError(offset int, err error) error {
+	return newParseErrorAt(p.data, offset, err.Error(), err)
+}
+
+func (p *parser) unexpectedEnd() error {
+	return newParseErrorAt(p.data, len(p.data), "unexpected end of JSON input", nil)
+}
+
+// kindError returns the error for a document whose root value is not of the expected kind
+func (p *parser) kindError(value any, expected Kind) error {
+	mapper := newMapper(value)
+	message := fmt.Sprintf("cannot unmarshal %v into %v", mapper.Kind(), expected)
+	return newParseErrorAt(p.data, p.rootStart, message, nil)
+}
+
+// quoteChar formats r for error messages, e.g. 'x'
+func quoteChar(r rune) string {
+	if r == '\'' {
+		return `'\''`
+	}
+	if r == '"' {
+		return `'"'`
+	}
+	s := strconv.Quote(string(r))
+	return "'" + s[1:len(s)-1] + "'"
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// hexDigit returns the value of the hexadecimal digit c
+func hexDigit(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+// isIdentifierStart checks if r can start an unquoted JSON5 key
+func isIdentifierStart(r rune) bool {
+	return r == '$' || r == '_' || unicode.IsLetter(r) || unicode.Is(unicode.Nl, r)
+}
+
+// isIdentifierPart checks if r can be part of an unquoted JSON5 key
+func isIdentifierPart(r rune) bool {
+	return isIdentifierStart(r) || unicode.IsDigit(r) || unicode.In(r, unicode.Mn, unicode.Mc, unicode.Pc) ||
+		r == '\u200C' || r == '\u200D'
+}
+
+// newMapperFromParsed returns a JsonMapper holding a value returned by the parser
+func newMapperFromParsed(value any) JsonMapper {
+	switch v := value.(type) {
+	case map[string]any:
+		return newMapper(membersToPtrs(v))
+	case []any:
+		return newMapper(elementsToPtrs(v))
+	}
+	return newMapper(value)
+}
+
+// valuePtr returns a pointer to v, or nil if v is null, which is how JsonObject and JsonArray represent null
+func valuePtr(v any) *any {
+	if v == nil {
+		return nil
+	}
+	return &v
+}
+
+// membersToPtrs converts decoded members to the representation of JsonObject, in which null values are
+// nil pointers
+func membersToPtrs(members map[string]any) map[string]*any {
+	ptrs := make(map[string]*any, len(members))
+	for k, v := range members {
+		if v == nil {
+			ptrs[k] = nil
+			continue
+		}
+		v := v
+		ptrs[k] = &v
+	}
+	return ptrs
+}
+
+// elementsToPtrs converts decoded elements to the representation of JsonArray, in which null values are
+// nil pointers
+func elementsToPtrs(elements []any) []*any {
+	ptrs := make([]*any, 0, len(elements))
+	for _, v := range elements {
+		if v == nil {
+			ptrs = append(ptrs, nil)
+			continue
+		}
+		v := v
+		ptrs = append(ptrs, &v)
+	}
+	return ptrs
+}
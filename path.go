@@ -0,0 +1,133 @@
+package jogson
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Path represents the location of a value in a JSON document. Every element of the path is either
+// an object key or an array index. The string representation of a Path is a JSON Pointer (RFC 6901),
+// e.g. "/children/0/name", and the root of the document is represented by an empty Path.
+type Path []string
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// ParsePath parses a JSON Pointer, e.g. "/children/0/name", into a Path. If the pointer is not empty
+// and does not start with '/', InvalidPathErr is returned.
+func ParsePath(pointer string) (Path, error) {
+	if pointer == "" {
+		return Path{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, createInvalidPathErr(pointer)
+	}
+	segments := strings.Split(pointer[1:], "/")
+	path := make(Path, 0, len(segments))
+	for _, segment := range segments {
+		path = append(path, pointerUnescaper.Replace(segment))
+	}
+	return path, nil
+}
+
+// String returns the Path as a JSON Pointer.
+func (p Path) String() string {
+	var sb strings.Builder
+	for _, segment := range p {
+		sb.WriteByte('/')
+		sb.WriteString(pointerEscaper.Replace(segment))
+	}
+	return sb.String()
+}
+
+// AppendKey returns a new Path with the object key appended to it.
+func (p Path) AppendKey(key string) Path {
+	newPath := make(Path, len(p), len(p)+1)
+	copy(newPath, p)
+	return append(newPath, key)
+}
+
+// AppendIndex returns a new Path with the array index appended to it.
+func (p Path) AppendIndex(i int) Path {
+	return p.AppendKey(strconv.Itoa(i))
+}
+
+// Last returns the last element of the Path, i.e. the key or index of the value it points to. For the
+// root Path an empty string is returned.
+func (p Path) Last() string {
+	if len(p) == 0 {
+		return ""
+	}
+	return p[len(p)-1]
+}
+
+// Parent returns the Path of the container of the value the Path points to. The parent of the root Path
+// is the root Path.
+func (p Path) Parent() Path {
+	if len(p) == 0 {
+		return p
+	}
+	return p[:len(p)-1]
+}
+
+// pathPattern is a compiled JSONPath-like pattern, e.g. "$.users[*].password" or "$..token". Every element
+// of the pattern is either a key or index, "*" which matches any single key or index, or "**" which
+// matches any number of keys and indices.
+type pathPattern []string
+
+// compilePathPattern compiles a JSONPath-like pattern. The leading '$' is optional, '.' separates keys,
+// '..' matches any depth and brackets may contain an index, a quoted key or '*'.
+func compilePathPattern(pattern string) pathPattern {
+	pattern = strings.TrimPrefix(pattern, "$")
+	var compiled pathPattern
+	for i := 0; i < len(pattern); {
+		switch {
+		case strings.HasPrefix(pattern[i:], ".."):
+			compiled = append(compiled, "**")
+			i += 2
+		case pattern[i] == '.':
+			i++
+		case pattern[i] == '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				end = len(pattern) - i
+			}
+			compiled = append(compiled, strings.Trim(pattern[i+1:i+end], `'"`))
+			i += end + 1
+		default:
+			end := strings.IndexAny(pattern[i:], ".[")
+			if end < 0 {
+				end = len(pattern) - i
+			}
+			compiled = append(compiled, pattern[i:i+end])
+			i += end
+		}
+	}
+	return compiled
+}
+
+// matches checks if path matches the pattern
+func (p pathPattern) matches(path Path) bool {
+	if len(p) == 0 {
+		return len(path) == 0
+	}
+	if p[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if p[1:].matches(path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 || (p[0] != "*" && p[0] != path[0]) {
+		return false
+	}
+	return p[1:].matches(path[1:])
+}
+
+// join returns a new Path with all elements of other appended to it
+func (p Path) join(other Path) Path {
+	newPath := make(Path, 0, len(p)+len(other))
+	newPath = append(newPath, p...)
+	return append(newPath, other...)
+}
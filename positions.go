@@ -0,0 +1,131 @@
+package jogson
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// Position is a location in the parsed source. Offset is the 0-based byte offset, Line and Column are
+// 1-based and Column is counted in characters.
+type Position struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+// Span is the range of a key or value in the parsed source. End is the position right after the last
+// character, so a value spans the bytes data[Start.Offset:End.Offset].
+type Span struct {
+	Start Position
+	End   Position
+}
+
+// Positions holds the spans of all keys and values of a parsed document, which are recorded if
+// ParseOptions.RecordPositions is set. Paths are relative to the object or array the Positions were
+// retrieved from. Positions describe the source as it was parsed and are not updated when values are
+// added or removed.
+type Positions struct {
+	values map[string]Span
+	keys   map[string]Span
+	base   Path
+}
+
+// Value returns the span of the value at path. If no position was recorded for path, false is returned.
+func (p Positions) Value(path Path) (Span, bool) {
+	span, ok := p.values[p.base.join(path).String()]
+	return span, ok
+}
+
+// Key returns the span of the key, including the quotes, of the object member at path. If no position
+// was recorded for path or path points to an array element, false is returned.
+func (p Positions) Key(path Path) (Span, bool) {
+	span, ok := p.keys[p.base.join(path).String()]
+	return span, ok
+}
+
+// IsEmpty checks if no positions were recorded.
+func (p Positions) IsEmpty() bool {
+	return len(p.values) == 0
+}
+
+// sub returns the Positions of the value at the child key or index
+func (p Positions) sub(key string) Positions {
+	if p.values == nil {
+		return p
+	}
+	return Positions{values: p.values, keys: p.keys, base: p.base.AppendKey(key)}
+}
+
+// Positions returns the source positions of the JsonObject and its children. The positions are only
+// recorded if the object was parsed with ParseOptions.RecordPositions.
+func (o *JsonObject) Positions() Positions {
+	return o.positions
+}
+
+// Position returns the span of the value associated with key in the parsed source. If no position was
+// recorded, false is returned.
+func (o *JsonObject) Position(key string) (Span, bool) {
+	return o.positions.Value(Path{key})
+}
+
+// KeyPosition returns the span of key, including the quotes, in the parsed source. If no position was
+// recorded, false is returned.
+func (o *JsonObject) KeyPosition(key string) (Span, bool) {
+	return o.positions.Key(Path{key})
+}
+
+// Positions returns the source positions of the JsonArray and its elements. The positions are only
+// recorded if the array was parsed with ParseOptions.RecordPositions.
+func (a *JsonArray) Positions() Positions {
+	return a.positions
+}
+
+// Position returns the span of the element at index i in the parsed source. If no position was recorded,
+// false is returned.
+func (a *JsonArray) Position(i int) (Span, bool) {
+	return a.positions.Value(Path{}.AppendIndex(i))
+}
+
+// Positions returns the source positions of the JsonMapper and its children. The positions are only
+// recorded if the value was parsed with ParseOptions.RecordPositions.
+func (m *JsonMapper) Positions() Positions {
+	return m.positions
+}
+
+// positionRecorder records positions while parsing and converts byte offsets to lines and columns
+type positionRecorder struct {
+	data       []byte
+	lineStarts []int
+	positions  Positions
+}
+
+func newPositionRecorder(data []byte) *positionRecorder {
+	r := &positionRecorder{
+		data:       data,
+		lineStarts: []int{0},
+		positions:  Positions{values: make(map[string]Span), keys: make(map[string]Span)},
+	}
+	for i, b := range data {
+		if b == '\n' {
+			r.lineStarts = append(r.lineStarts, i+1)
+		}
+	}
+	return r
+}
+
+// position converts a byte offset into a Position
+func (r *positionRecorder) position(offset int) Position {
+	line := sort.Search(len(r.lineStarts), func(i int) bool {
+		return r.lineStarts[i] > offset
+	}) - 1
+	lineStart := r.lineStarts[line]
+	return Position{Offset: offset, Line: line + 1, Column: 1 + utf8.RuneCount(r.data[lineStart:offset])}
+}
+
+func (r *positionRecorder) recordValue(path Path, start int, end int) {
+	r.positions.values[path.String()] = Span{Start: r.position(start), End: r.position(end)}
+}
+
+func (r *positionRecorder) recordKey(path Path, start int, end int) {
+	r.positions.keys[path.String()] = Span{Start: r.position(start), End: r.position(end)}
+}
@@ -0,0 +1,198 @@
+package jogson
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// NewObjectFromProperties parses a Java .properties file into a JsonObject. Dotted keys, e.g.
+// "db.pool.size=10", are converted into nested objects, and objects whose keys are the indices 0 to n-1
+// into arrays. Keys and values are separated by '=', ':' or whitespace. Lines that start with '#' or '!' are
+// comments and a line that ends with an odd number of backslashes is continued on the next line. The
+// escapes \t, \n, \r, \f and \uXXXX are supported and a backslash before any other character is removed.
+// The file is read as UTF-8. If the file is not valid or a key is both a value and an object, e.g. "a=1"
+// and "a.b=2", ParseErr is returned.
+func NewObjectFromProperties(data []byte, opts INIOptions) (*JsonObject, error) {
+	b := newKeyValueBuilder(data, opts.DuplicateKeys)
+	offset := 0
+	for offset < len(data) {
+		lineStart := offset
+		var line []byte
+		line, offset = readLine(data, offset)
+		text := strings.TrimLeft(string(line), " \t\f")
+		lineStart += len(line) - len(text)
+		if text == "" || text[0] == '#' || text[0] == '!' {
+			continue
+		}
+		for endsWithEscape(text) && offset < len(data) {
+			line, offset = readLine(data, offset)
+			text = text[:len(text)-1] + strings.TrimLeft(string(line), " \t\f")
+		}
+		if endsWithEscape(text) {
+			text = text[:len(text)-1]
+		}
+
+		keyEnd := 0
+		for keyEnd < len(text) && !strings.ContainsRune("=: \t\f", rune(text[keyEnd])) {
+			if text[keyEnd] == '\\' && keyEnd+1 < len(text) {
+				keyEnd++
+			}
+			keyEnd++
+		}
+		value := strings.TrimLeft(text[keyEnd:], " \t\f")
+		if value != "" && (value[0] == '=' || value[0] == ':') {
+			value = strings.TrimLeft(value[1:], " \t\f")
+		}
+
+		key, err := unescapeProperties(text[:keyEnd])
+		if err != nil {
+			return &JsonObject{}, newParseErrorAt(data, lineStart, err.Error(), nil)
+		}
+		keys, ok := splitDottedKey(key)
+		if !ok {
+			return &JsonObject{}, newParseErrorAt(data, lineStart, "empty key", nil)
+		}
+		value, err = unescapeProperties(value)
+		if err != nil {
+			return &JsonObject{}, newParseErrorAt(data, lineStart+keyEnd, err.Error(), nil)
+		}
+		var v any = value
+		if opts.InferTypes {
+			v = inferType(value)
+		}
+		err = b.set(keys, v, lineStart)
+		if err != nil {
+			return &JsonObject{}, err
+		}
+	}
+	return b.build(), nil
+}
+
+// NewObjectFromPropertiesFile reads a .properties file from the given path and parses it into a JsonObject.
+// See NewObjectFromProperties.
+func NewObjectFromPropertiesFile(path string, opts INIOptions) (*JsonObject, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return &JsonObject{}, err
+	}
+	obj, err := NewObjectFromProperties(file, opts)
+	return obj, withParseErrorPath(err, path)
+}
+
+// PropertiesString returns a .properties representation of the JsonObject. Nested objects and arrays are
+// written with dotted keys, e.g. "db.pool.size" or "hosts.0". Keys are sorted, null is written as an empty
+// value and empty objects and arrays are left out. Special characters are escaped, non-ASCII characters are
+// written as UTF-8. If a key cannot be represented, e.g. because it contains a dot, InvalidPathErr is
+// returned.
+func (o *JsonObject) PropertiesString() (string, error) {
+	var entries []keyValueEntry
+	for _, key := range sortedKeys(o.object) {
+		entries = flattenKeyValues(entries, Path{key}, derefValue(o.object[key]))
+	}
+	var sb strings.Builder
+	for _, entry := range entries {
+		for _, key := range entry.keys {
+			if key == "" || strings.Contains(key, ".") {
+				return "", createInvalidPathErr(entry.keys.String())
+			}
+		}
+		key := escapeProperties(strings.Join(entry.keys, "."), true)
+		value := escapeProperties(formatText(entry.value, ""), false)
+		sb.WriteString(key + "=" + value + "\n")
+	}
+	return sb.String(), nil
+}
+
+// unescapeProperties replaces the escapes in a key or value
+func unescapeProperties(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u':
+			if i+5 > len(s) {
+				return "", fmt.Errorf("malformed \\uXXXX escape")
+			}
+			r, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("malformed \\uXXXX escape")
+			}
+			i += 4
+			// characters outside the BMP are written as surrogate pairs, e.g. "\uD83D\uDE00"
+			if utf16.IsSurrogate(rune(r)) && strings.HasPrefix(s[i+1:], `\u`) && i+7 <= len(s) {
+				if low, err := strconv.ParseUint(s[i+3:i+7], 16, 16); err == nil {
+					if decoded := utf16.DecodeRune(rune(r), rune(low)); decoded != unicode.ReplacementChar {
+						sb.WriteRune(decoded)
+						i += 6
+						continue
+					}
+				}
+			}
+			sb.WriteRune(rune(r))
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String(), nil
+}
+
+// escapeProperties escapes a key or value. In keys, all separators and comment characters are escaped, in
+// values only leading whitespace.
+func escapeProperties(s string, isKey bool) string {
+	var sb strings.Builder
+	for i, r := range s {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case ' ':
+			if isKey || i == 0 {
+				sb.WriteByte('\\')
+			}
+			sb.WriteByte(' ')
+		case '=', ':', '#', '!':
+			if isKey {
+				sb.WriteByte('\\')
+			}
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// endsWithEscape checks if s ends with an odd number of backslashes
+func endsWithEscape(s string) bool {
+	n := 0
+	for n < len(s) && s[len(s)-1-n] == '\\' {
+		n++
+	}
+	return n%2 == 1
+}
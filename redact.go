@@ -0,0 +1,198 @@
+package jogson
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// RedactionMode defines how a value that matches a RedactionRule is redacted
+type RedactionMode int
+
+const (
+	// RedactRemove removes the value from its parent object or array
+	RedactRemove RedactionMode = iota
+	// RedactReplace replaces the value with a placeholder string
+	RedactReplace
+	// RedactMask replaces all characters of the value but the last RedactionRule.KeepLast characters
+	// with RedactionRule.MaskChar
+	RedactMask
+	// RedactHash replaces the value with the hex encoded HMAC-SHA-256 of the value, keyed with
+	// RedactionRule.HashKey
+	RedactHash
+)
+
+const defaultRedactionPlaceholder = "[REDACTED]"
+
+// RedactionRule defines which values should be redacted and how. A value is matched by a rule if its
+// key is one of Keys (case-insensitive), its key matches one of KeyPatterns or its path matches one of
+// Paths. Keys and KeyPatterns only match members of objects, not elements of arrays. Paths are
+// JSONPath-like patterns, e.g. "$.users[*].password" or "$..token".
+type RedactionRule struct {
+	Keys        []string
+	KeyPatterns []*regexp.Regexp
+	Paths       []string
+	Mode        RedactionMode
+	// Placeholder replaces the value in RedactReplace mode and objects or arrays in RedactMask mode.
+	// Defaults to "[REDACTED]".
+	Placeholder string
+	// KeepLast is the number of trailing characters that are left visible in RedactMask mode. At most half of
+	// the characters are left visible, so short values are never fully shown.
+	KeepLast int
+	// MaskChar is the character used in RedactMask mode. Defaults to '*'.
+	MaskChar rune
+	// HashKey is the secret key of the HMAC in RedactHash mode. A plain hash of values with few possibilities,
+	// e.g. card numbers, can be reversed by brute force, the HMAC cannot without the key. Values hashed with
+	// the same key have the same hash, so they can still be correlated. If empty, a random key is generated
+	// for every call to Redact.
+	HashKey []byte
+}
+
+// RedactionPolicy is a list of rules that are applied by Redact. If a value matches multiple rules,
+// the first rule is applied. Once a value is redacted, its children are not visited.
+type RedactionPolicy struct {
+	Rules []RedactionRule
+}
+
+// NewRedactionPolicy returns a new RedactionPolicy with the given rules.
+func NewRedactionPolicy(rules ...RedactionRule) RedactionPolicy {
+	return RedactionPolicy{Rules: rules}
+}
+
+// Redact returns a copy of the JsonObject in which all values, including nested ones, that match the
+// policy are redacted. The original JsonObject is not changed.
+func (o *JsonObject) Redact(policy RedactionPolicy) *JsonObject {
+	obj := newObjectFromMap(copyMembers(o.object))
+	obj.TransformValues(policy.transformFunc(false))
+	return obj
+}
+
+// Redact returns a copy of the JsonArray in which all values, including nested ones, that match the
+// policy are redacted. The original JsonArray is not changed.
+func (a *JsonArray) Redact(policy RedactionPolicy) *JsonArray {
+	arr := newArrayFromSlice(copyElements(a.elements))
+	arr.TransformValues(policy.transformFunc(true))
+	return arr
+}
+
+// transformFunc returns a TransformFunc that applies the policy to an object or, if rootIsArray is set, an
+// array
+func (p RedactionPolicy) transformFunc(rootIsArray bool) TransformFunc {
+	patterns := make([][]pathPattern, len(p.Rules))
+	rules := make([]RedactionRule, len(p.Rules))
+	copy(rules, p.Rules)
+	for i, rule := range rules {
+		if rule.Mode == RedactHash && len(rule.HashKey) == 0 {
+			rules[i].HashKey = randomHashKey()
+		}
+		for _, path := range rule.Paths {
+			patterns[i] = append(patterns[i], compilePathPattern(path))
+		}
+	}
+	// arrays holds the paths of the arrays visited so far, which tells if the last element of a path is an
+	// index or a key
+	arrays := map[string]bool{"": rootIsArray}
+	return func(path Path, value JsonMapper) (JsonMapper, TransformAction) {
+		isIndex := arrays[path.Parent().String()]
+		for i, rule := range rules {
+			if rule.matches(path, isIndex, patterns[i]) {
+				return rule.apply(value)
+			}
+		}
+		if value.IsArray() {
+			arrays[path.String()] = true
+		}
+		return value, TransformKeep
+	}
+}
+
+// matches checks if the value at path is matched by the rule. isIndex tells if the value is an element of
+// an array, which is not matched by Keys and KeyPatterns.
+func (r RedactionRule) matches(path Path, isIndex bool, patterns []pathPattern) bool {
+	if !isIndex {
+		key := path.Last()
+		for _, k := range r.Keys {
+			if strings.EqualFold(k, key) {
+				return true
+			}
+		}
+		for _, re := range r.KeyPatterns {
+			if re.MatchString(key) {
+				return true
+			}
+		}
+	}
+	for _, pattern := range patterns {
+		if pattern.matches(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply redacts value according to the rule's mode
+func (r RedactionRule) apply(value JsonMapper) (JsonMapper, TransformAction) {
+	placeholder := r.Placeholder
+	if placeholder == "" {
+		placeholder = defaultRedactionPlaceholder
+	}
+	if r.Mode == RedactRemove {
+		return value, TransformDelete
+	}
+	if value.IsNull() && r.Mode != RedactReplace {
+		return value, TransformKeep
+	}
+	var redacted string
+	switch r.Mode {
+	case RedactMask:
+		if value.IsObject() || value.IsArray() {
+			redacted = placeholder
+		} else {
+			redacted = maskString(redactedValueString(value), r.KeepLast, r.MaskChar)
+		}
+	case RedactHash:
+		mac := hmac.New(sha256.New, r.HashKey)
+		mac.Write([]byte(redactedValueString(value)))
+		redacted = hex.EncodeToString(mac.Sum(nil))
+	default:
+		redacted = placeholder
+	}
+	return newMapper(redacted), TransformReplace
+}
+
+// redactedValueString returns the string that is masked or hashed. Strings are used as they are and
+// all other values as JSON.
+func redactedValueString(value JsonMapper) string {
+	v := convertMapperToAny(value)
+	if s, ok := v.(string); ok {
+		return s
+	}
+	jsonBytes, _ := marshal(v)
+	return string(jsonBytes)
+}
+
+// maskString replaces all characters of s but the last keepLast with maskChar. At most half of the
+// characters are kept.
+func maskString(s string, keepLast int, maskChar rune) string {
+	if maskChar == 0 {
+		maskChar = '*'
+	}
+	runes := []rune(s)
+	if keepLast > len(runes)/2 {
+		keepLast = len(runes) / 2
+	}
+	for i := 0; i < len(runes)-keepLast; i++ {
+		runes[i] = maskChar
+	}
+	return string(runes)
+}
+
+// randomHashKey returns a random key for RedactHash rules without a HashKey
+func randomHashKey() []byte {
+	key := make([]byte, sha256.Size)
+	_, _ = rand.Read(key)
+	return key
+}
@@ -0,0 +1,450 @@
+package jogson
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Repair describes a change that was made to malformed JSON to make it valid
+type Repair struct {
+	// Offset is the byte offset in the input at which the change was made
+	Offset int
+	// Message describes the change, e.g. "added missing '}'"
+	Message string
+}
+
+// String returns the Repair as "<offset>: <message>".
+func (r Repair) String() string {
+	return fmt.Sprintf("%d: %v", r.Offset, r.Message)
+}
+
+// RepairJSON recovers as much as possible from truncated or malformed JSON, e.g. output of language models
+// or truncated log lines, and returns valid JSON together with the list of repairs that were applied. It
+// closes unterminated strings, arrays and objects, drops incomplete members and trailing text, removes
+// comments and trailing commas, adds missing commas and colons, quotes unquoted keys and single-quoted
+// strings, escapes newlines in strings and replaces Python-style True, False and None. If data does not
+// contain any JSON value, a ParseError is returned.
+func RepairJSON(data []byte) ([]byte, []Repair, error) {
+	r := &repairer{data: data}
+	err := r.repairDocument()
+	if err != nil {
+		return nil, nil, err
+	}
+	return r.out, r.repairs, nil
+}
+
+// NewMapperFromBytesLenient parses malformed JSON data from a byte slice after repairing it with
+// RepairJSON. The repairs that were applied are returned with the JsonMapper.
+func NewMapperFromBytesLenient(data []byte) (JsonMapper, []Repair, error) {
+	repaired, repairs, err := RepairJSON(data)
+	if err != nil {
+		return JsonMapper{}, nil, err
+	}
+	mapper, err := NewMapperFromBytes(repaired)
+	if err != nil {
+		return JsonMapper{}, nil, err
+	}
+	return mapper, repairs, nil
+}
+
+// NewMapperFromStringLenient parses malformed JSON from a string after repairing it with RepairJSON. The
+// repairs that were applied are returned with the JsonMapper.
+func NewMapperFromStringLenient(data string) (JsonMapper, []Repair, error) {
+	return NewMapperFromBytesLenient([]byte(data))
+}
+
+// repairer writes a repaired copy of data to out
+type repairer struct {
+	data    []byte
+	offset  int
+	out     []byte
+	repairs []Repair
+}
+
+func (r *repairer) addRepair(offset int, format string, args ...any) {
+	r.repairs = append(r.repairs, Repair{Offset: offset, Message: fmt.Sprintf(format, args...)})
+}
+
+func (r *repairer) repairDocument() error {
+	r.skipWhitespace()
+	if r.offset < len(r.data) && !r.atValueStart() {
+		start := r.offset
+		next := bytes.IndexAny(r.data[r.offset:], "{[")
+		if next < 0 {
+			return newParseErrorAt(r.data, r.offset, "no JSON value found", nil)
+		}
+		r.offset += next
+		r.addRepair(start, "removed leading text")
+	}
+	if r.offset >= len(r.data) {
+		return newParseErrorAt(r.data, len(r.data), "unexpected end of JSON input", nil)
+	}
+	r.repairValue()
+	r.skipWhitespace()
+	if r.offset < len(r.data) {
+		r.addRepair(r.offset, "removed trailing text")
+	}
+	return nil
+}
+
+// repairValue repairs the value at the current offset, which must not be at the end of the input
+func (r *repairer) repairValue() {
+	switch c := r.data[r.offset]; {
+	case c == '{':
+		r.repairObject()
+	case c == '[':
+		r.repairArray()
+	case c == '"' || c == '\'':
+		r.repairString()
+	case c == '-' || c == '+' || c == '.' || isDigit(c):
+		r.repairNumber()
+	default:
+		r.repairWord()
+	}
+}
+
+func (r *repairer) repairObject() {
+	r.out = append(r.out, '{')
+	r.offset++
+	first := true
+	for {
+		r.skipWhitespace()
+		if r.offset >= len(r.data) {
+			r.addRepair(r.offset, "added missing '}'")
+			r.out = append(r.out, '}')
+			return
+		}
+		switch r.data[r.offset] {
+		case '}':
+			r.out = append(r.out, '}')
+			r.offset++
+			return
+		case ']':
+			// the ']' closes an enclosing array
+			r.addRepair(r.offset, "added missing '}'")
+			r.out = append(r.out, '}')
+			return
+		case ',':
+			r.addRepair(r.offset, "removed extra ','")
+			r.offset++
+			continue
+		}
+		memberStart := len(r.out)
+		memberOffset := r.offset
+		if !first {
+			r.out = append(r.out, ',')
+		}
+		if !r.repairKey() {
+			r.out = r.out[:memberStart]
+			continue
+		}
+		r.skipWhitespace()
+		if r.offset < len(r.data) && (r.data[r.offset] == ':' || r.data[r.offset] == '=') {
+			r.offset++
+		} else if r.offset < len(r.data) {
+			r.addRepair(r.offset, "added missing ':'")
+		}
+		r.out = append(r.out, ':')
+		r.skipWhitespace()
+		if r.offset >= len(r.data) {
+			r.addRepair(memberOffset, "removed incomplete member")
+			r.out = r.out[:memberStart]
+			continue
+		}
+		if c := r.data[r.offset]; c == ',' || c == '}' {
+			r.addRepair(r.offset, "added missing value")
+			r.out = append(r.out, "null"...)
+		} else {
+			r.repairValue()
+		}
+		first = false
+		r.repairSeparator('}')
+	}
+}
+
+// repairKey repairs an object key. If no key can be found at the current offset, the character is
+// skipped and false is returned.
+func (r *repairer) repairKey() bool {
+	c := r.data[r.offset]
+	if c == '"' || c == '\'' {
+		r.repairString()
+		return true
+	}
+	start := r.offset
+	for r.offset < len(r.data) && !bytes.ContainsRune([]byte(" \t\r\n:,{}[]\"'"), rune(r.data[r.offset])) {
+		r.offset++
+	}
+	if r.offset == start {
+		r.addRepair(start, "removed invalid character %v", quoteChar(rune(c)))
+		r.offset++
+		return false
+	}
+	r.addRepair(start, "quoted key")
+	key, _ := marshal(string(r.data[start:r.offset]))
+	r.out = append(r.out, key...)
+	return true
+}
+
+func (r *repairer) repairArray() {
+	r.out = append(r.out, '[')
+	r.offset++
+	first := true
+	for {
+		r.skipWhitespace()
+		if r.offset >= len(r.data) {
+			r.addRepair(r.offset, "added missing ']'")
+			r.out = append(r.out, ']')
+			return
+		}
+		switch c := r.data[r.offset]; {
+		case c == ']':
+			r.out = append(r.out, ']')
+			r.offset++
+			return
+		case c == '}':
+			// the '}' closes an enclosing object
+			r.addRepair(r.offset, "added missing ']'")
+			r.out = append(r.out, ']')
+			return
+		case c == ',':
+			r.addRepair(r.offset, "removed extra ','")
+			r.offset++
+			continue
+		case c == ':' || !(isValueStart(c) || isWordChar(c)):
+			r.addRepair(r.offset, "removed invalid character %v", quoteChar(rune(c)))
+			r.offset++
+			continue
+		}
+		if !first {
+			r.out = append(r.out, ',')
+		}
+		r.repairValue()
+		first = false
+		r.repairSeparator(']')
+	}
+}
+
+// repairSeparator consumes the comma after a member or element. If the comma is missing, it is added by the
+// next member or element. A trailing comma before closing is removed.
+func (r *repairer) repairSeparator(closing byte) {
+	r.skipWhitespace()
+	if r.offset >= len(r.data) {
+		return
+	}
+	switch r.data[r.offset] {
+	case ',':
+		commaOffset := r.offset
+		r.offset++
+		r.skipWhitespace()
+		if r.offset < len(r.data) && (r.data[r.offset] == closing || r.data[r.offset] == '}' || r.data[r.offset] == ']') {
+			r.addRepair(commaOffset, "removed trailing ','")
+		}
+	case '}', ']':
+	default:
+		r.addRepair(r.offset, "added missing ','")
+	}
+}
+
+func (r *repairer) repairString() {
+	quote := r.data[r.offset]
+	if quote == '\'' {
+		r.addRepair(r.offset, "replaced single quotes with double quotes")
+	}
+	r.offset++
+	r.out = append(r.out, '"')
+	for r.offset < len(r.data) {
+		c := r.data[r.offset]
+		switch {
+		case c == quote && (quote == '\'' || r.isClosingQuote()):
+			r.out = append(r.out, '"')
+			r.offset++
+			return
+		case c == '"':
+			r.addRepair(r.offset, "escaped quote in string")
+			r.out = append(r.out, '\\', '"')
+			r.offset++
+		case c == '\\':
+			r.repairEscape()
+		case c == '\n':
+			r.addRepair(r.offset, "escaped newline in string")
+			r.out = append(r.out, '\\', 'n')
+			r.offset++
+		case c < 0x20:
+			r.addRepair(r.offset, "escaped control character in string")
+			r.out = append(r.out, fmt.Sprintf("\\u%04x", c)...)
+			r.offset++
+		default:
+			r.out = append(r.out, c)
+			r.offset++
+		}
+	}
+	r.addRepair(r.offset, "added missing closing quote")
+	r.out = append(r.out, '"')
+}
+
+// isClosingQuote checks if the double quote at the current offset closes the string. A quote that is
+// followed by anything but a delimiter, e.g. in "he said "hi"", is regarded as part of the string.
+func (r *repairer) isClosingQuote() bool {
+	i := r.offset + 1
+	for i < len(r.data) && (r.data[i] == ' ' || r.data[i] == '\t' || r.data[i] == '\r' || r.data[i] == '\n') {
+		i++
+	}
+	return i >= len(r.data) || bytes.IndexByte([]byte(",:}]\"/"), r.data[i]) >= 0
+}
+
+// repairEscape copies the escape sequence at the current offset. Invalid escape sequences are turned into
+// an escaped backslash.
+func (r *repairer) repairEscape() {
+	if r.offset+1 >= len(r.data) {
+		r.addRepair(r.offset, "removed incomplete escape sequence")
+		r.offset++
+		return
+	}
+	c := r.data[r.offset+1]
+	switch c {
+	case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+		r.out = append(r.out, '\\', c)
+		r.offset += 2
+		return
+	case '\'':
+		r.out = append(r.out, '\'')
+		r.offset += 2
+		return
+	case 'u':
+		valid := r.offset+6 <= len(r.data)
+		for i := r.offset + 2; valid && i < r.offset+6; i++ {
+			_, valid = hexDigit(r.data[i])
+		}
+		if valid {
+			r.out = append(r.out, r.data[r.offset:r.offset+6]...)
+			r.offset += 6
+			return
+		}
+	}
+	r.addRepair(r.offset, "escaped backslash in string")
+	r.out = append(r.out, '\\', '\\')
+	r.offset++
+}
+
+func (r *repairer) repairNumber() {
+	start := r.offset
+	for r.offset < len(r.data) && bytes.IndexByte([]byte("+-.0123456789eE"), r.data[r.offset]) >= 0 {
+		r.offset++
+	}
+	text := string(r.data[start:r.offset])
+	p := newParser([]byte(text), ParseOptions{})
+	if value, err := p.parseDocument(); err == nil {
+		if _, ok := value.(float64); ok {
+			r.out = append(r.out, text...)
+			return
+		}
+	}
+	cleaned := strings.TrimRight(strings.TrimPrefix(text, "+"), "+-.eE")
+	if f, err := strconv.ParseFloat(cleaned, 64); err == nil {
+		r.addRepair(start, "replaced invalid number %v", text)
+		r.out = strconv.AppendFloat(r.out, f, 'g', -1, 64)
+		return
+	}
+	r.addRepair(start, "replaced invalid number %v with null", text)
+	r.out = append(r.out, "null"...)
+}
+
+// repairWord repairs literals, e.g. Python's True and None or truncated literals such as "tr". Other words
+// are turned into strings.
+func (r *repairer) repairWord() {
+	start := r.offset
+	for r.offset < len(r.data) && isWordChar(r.data[r.offset]) {
+		r.offset++
+	}
+	if r.offset == start {
+		r.addRepair(start, "replaced invalid character %v with null", quoteChar(rune(r.data[start])))
+		r.out = append(r.out, "null"...)
+		r.offset++
+		return
+	}
+	word := string(r.data[start:r.offset])
+	var literal string
+	switch word {
+	case "true", "false", "null":
+		r.out = append(r.out, word...)
+		return
+	case "True", "TRUE":
+		literal = "true"
+	case "False", "FALSE":
+		literal = "false"
+	case "None", "NULL", "Null", "nil", "undefined", "NaN", "Infinity":
+		literal = "null"
+	default:
+		for _, l := range []string{"true", "false", "null"} {
+			if r.offset == len(r.data) && strings.HasPrefix(l, word) {
+				r.addRepair(start, "completed truncated literal %v", word)
+				r.out = append(r.out, l...)
+				return
+			}
+		}
+		r.addRepair(start, "quoted %v", word)
+		quoted, _ := marshal(word)
+		r.out = append(r.out, quoted...)
+		return
+	}
+	r.addRepair(start, "replaced %v with %v", word, literal)
+	r.out = append(r.out, literal...)
+}
+
+// skipWhitespace skips whitespace and comments
+func (r *repairer) skipWhitespace() {
+	for r.offset < len(r.data) {
+		switch c := r.data[r.offset]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			r.offset++
+		case c == '/' && r.offset+1 < len(r.data) && r.data[r.offset+1] == '/':
+			r.addRepair(r.offset, "removed comment")
+			end := bytes.IndexByte(r.data[r.offset:], '\n')
+			if end < 0 {
+				r.offset = len(r.data)
+			} else {
+				r.offset += end
+			}
+		case c == '/' && r.offset+1 < len(r.data) && r.data[r.offset+1] == '*':
+			r.addRepair(r.offset, "removed comment")
+			end := bytes.Index(r.data[r.offset+2:], []byte("*/"))
+			if end < 0 {
+				r.offset = len(r.data)
+			} else {
+				r.offset += end + 4
+			}
+		default:
+			return
+		}
+	}
+}
+
+// atValueStart checks if a JSON value starts at the current offset. Words are only regarded as values if
+// they are literals.
+func (r *repairer) atValueStart() bool {
+	c := r.data[r.offset]
+	if !isWordChar(c) || isDigit(c) {
+		return isValueStart(c)
+	}
+	end := r.offset
+	for end < len(r.data) && isWordChar(r.data[end]) {
+		end++
+	}
+	switch string(r.data[r.offset:end]) {
+	case "true", "false", "null", "True", "False", "None":
+		return true
+	}
+	return false
+}
+
+// isValueStart checks if c can start a JSON value
+func isValueStart(c byte) bool {
+	return bytes.IndexByte([]byte("{[\"'-+.0123456789tfnTFN"), c) >= 0
+}
+
+// isWordChar checks if c can be part of an unquoted word
+func isWordChar(c byte) bool {
+	return c == '_' || c == '$' || isDigit(c) || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || c >= 0x80
+}
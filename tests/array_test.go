@@ -11,19 +11,19 @@ import (
 
 func TestArrayAsStringArray(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonStringArrayTest)
-	array := mapper.AsArray
+	array, _ := mapper.AsArray()
 	assert.ElementsMatch(t, []string{"Jason", "Chris", "Rachel"}, array.AsStringArray())
 }
 
 func TestArrayAsIntArray(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonIntArrayTest)
-	array := mapper.AsArray
+	array, _ := mapper.AsArray()
 	assert.ElementsMatch(t, []int{0, 15, -54, -346, 9223372036854775807}, array.AsIntArray())
 }
 
 func TestArrayAsFloatArray(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonFloatArrayTest)
-	array := mapper.AsArray
+	array, _ := mapper.AsArray()
 	assert.ElementsMatch(t, []float64{15.13, 2, 45.3984, -1.81, 9.223372036854776}, array.AsFloatArray())
 }
 
@@ -73,7 +73,7 @@ func TestArrayContainsFloat(t *testing.T) {
 
 func TestArrayAs2DArray(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(json2DIntArrayTest)
-	array := mapper.AsArray
+	array, _ := mapper.AsArray()
 	jsonArray := array.As2DArray()
 	assert.ElementsMatch(t, []int{1, 2}, jsonArray[0].AsIntArray())
 	assert.ElementsMatch(t, []int{3, 4}, array.As2DArray()[1].AsIntArray())
@@ -81,41 +81,41 @@ func TestArrayAs2DArray(t *testing.T) {
 
 func TestArrayAsObjectArray(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectArrayTest)
-	array := mapper.AsArray
+	array, _ := mapper.AsArray()
 	assert.Equal(t, "Jason", array.AsObjectArray()[0].GetString("name"))
 	assert.Equal(t, "Chris", array.AsObjectArray()[1].GetString("name"))
 }
 
 func TestArrayGetMapper(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonAnyArrayTest)
-	array := mapper.AsArray
+	array, _ := mapper.AsArray()
 
 	elementMapper := array.Get(0)
 	assert.NoError(t, array.LastError)
-	assert.Equal(t, "Jason", elementMapper.AsString)
+	assert.Equal(t, `"Jason"`, elementMapper.String())
 
 	array.LastError = nil
 	elementMapper = array.Get(1)
 	assert.NoError(t, array.LastError)
-	assert.Equal(t, 15, elementMapper.AsInt)
-	assert.True(t, elementMapper.IsInt)
+	assert.Equal(t, "15", elementMapper.String())
+	assert.True(t, elementMapper.IsInt())
 
 	array.LastError = nil
 	elementMapper = array.Get(2)
 	assert.NoError(t, array.LastError)
-	assert.True(t, elementMapper.IsNull)
+	assert.True(t, elementMapper.IsNull())
 
 	array.LastError = nil
 	elementMapper = array.Get(3)
 	assert.NoError(t, array.LastError)
-	assert.Equal(t, 1.81, elementMapper.AsFloat)
-	assert.True(t, elementMapper.IsFloat)
+	assert.Equal(t, "1.81", elementMapper.String())
+	assert.True(t, elementMapper.IsFloat())
 
 	array.LastError = nil
 	elementMapper = array.Get(4)
 	assert.NoError(t, array.LastError)
-	assert.Equal(t, true, elementMapper.AsBool)
-	assert.True(t, elementMapper.IsBool)
+	assert.Equal(t, "true", elementMapper.String())
+	assert.True(t, elementMapper.IsBool())
 }
 
 func TestArrayGetStringN(t *testing.T) {
@@ -152,7 +152,7 @@ func TestArrayGetBoolN(t *testing.T) {
 
 func TestArrayGetString(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonAnyArrayTest)
-	array := mapper.AsArray
+	array, _ := mapper.AsArray()
 
 	s := array.GetString(0)
 	assert.NoError(t, array.LastError)
@@ -181,7 +181,7 @@ func TestArrayGetString(t *testing.T) {
 
 func TestArrayGetStringFails(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonAnyArrayTest)
-	array := mapper.AsArray
+	array, _ := mapper.AsArray()
 
 	s := array.GetString(10)
 	assert.ErrorIs(t, array.LastError, jogson.IndexOutOfRangeErr)
@@ -194,7 +194,7 @@ func TestArrayGetStringFails(t *testing.T) {
 
 func TestArrayGetInt(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonIntArrayTest)
-	array := mapper.AsArray
+	array, _ := mapper.AsArray()
 
 	i := array.GetInt(0)
 	assert.NoError(t, array.LastError)
@@ -215,7 +215,7 @@ func TestArrayGetInt(t *testing.T) {
 
 func TestArrayGetIntFails(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonAnyArrayTest)
-	array := mapper.AsArray
+	array, _ := mapper.AsArray()
 
 	i := array.GetInt(10)
 	assert.ErrorIs(t, array.LastError, jogson.IndexOutOfRangeErr)
@@ -232,7 +232,7 @@ func TestArrayGetIntFails(t *testing.T) {
 
 func TestArrayGetFloat(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonFloatArrayTest)
-	array := mapper.AsArray
+	array, _ := mapper.AsArray()
 
 	f := array.GetFloat(0)
 	assert.NoError(t, array.LastError)
@@ -257,7 +257,7 @@ func TestArrayGetFloat(t *testing.T) {
 
 func TestArrayGetFloatFails(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonAnyArrayTest)
-	array := mapper.AsArray
+	array, _ := mapper.AsArray()
 
 	f := array.GetFloat(10)
 	assert.ErrorIs(t, array.LastError, jogson.IndexOutOfRangeErr)
@@ -274,7 +274,7 @@ func TestArrayGetFloatFails(t *testing.T) {
 
 func TestArrayGetArray(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(json2DArrayTest)
-	array := mapper.AsArray
+	array, _ := mapper.AsArray()
 
 	nestedArray := array.GetArray(0)
 	assert.ElementsMatch(t, []int{1, 2}, nestedArray.AsIntArray())
@@ -282,7 +282,7 @@ func TestArrayGetArray(t *testing.T) {
 
 func TestArrayGetArrayFails(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(json2DArrayTest)
-	array := mapper.AsArray
+	array, _ := mapper.AsArray()
 
 	innerArr := array.GetArray(5)
 	assert.ErrorIs(t, array.LastError, jogson.IndexOutOfRangeErr)
@@ -299,7 +299,7 @@ func TestArrayGetArrayFails(t *testing.T) {
 
 func TestArrayGetObject(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectArrayTest)
-	array := mapper.AsArray
+	array, _ := mapper.AsArray()
 
 	obj := array.GetObject(1)
 	assert.Equal(t, "Chris", obj.GetString("name"))
@@ -307,7 +307,7 @@ func TestArrayGetObject(t *testing.T) {
 
 func TestArrayGetObjectFails(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonArrayWithNullTest)
-	array := mapper.AsArray
+	array, _ := mapper.AsArray()
 
 	obj := array.GetObject(10)
 	assert.ErrorIs(t, array.LastError, jogson.IndexOutOfRangeErr)
@@ -342,7 +342,8 @@ func TestArrayGetTime(t *testing.T) {
 func TestArrayGetUUID(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonUUIDArrayTest)
 	assert.NoError(t, err)
-	uuid, err := mapper.AsArray.Get(0).AsUUID()
+	mapperArray, _ := mapper.AsArray()
+	uuid, err := mapperArray.Get(0).AsUUID()
 	assert.NoError(t, err)
 	assert.Equal(t, "870fb3fd-d177-4ac4-a648-a33afd5ab288", uuid.String())
 
@@ -358,7 +359,7 @@ func TestArrayGetUUID(t *testing.T) {
 
 func TestArrayIsNull(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectTest)
-	object := mapper.AsObject
+	object, _ := mapper.AsObject()
 	nullArray := object.GetArray("address")
 	assert.True(t, nullArray.IsNull())
 }
@@ -375,43 +376,50 @@ func TestArrayIsEmpty(t *testing.T) {
 func TestArrayPrintString(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonObjectArrayTest)
 	assert.NoError(t, err)
-	s := mapper.AsArray.String()
+	array, _ := mapper.AsArray()
+	s := array.String()
 	assert.Equal(t, `[{"name":"Jason"},{"name":"Chris"}]`, s)
 }
 
 func TestArrayPrettyString(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonObjectArrayTest)
 	assert.NoError(t, err)
+	array, _ := mapper.AsArray()
 	expectedArrayStr := "[\n  {\n    \"name\": \"Jason\"\n  },\n  {\n    \"name\": \"Chris\"\n  }\n]"
-	assert.Equal(t, expectedArrayStr, mapper.AsArray.PrettyString())
+	assert.Equal(t, expectedArrayStr, array.PrettyString())
 }
 
 func TestArrayFilter(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonObjectArrayTest)
 	assert.NoError(t, err)
-	filteredArr := mapper.AsArray.Filter(func(element jogson.JsonMapper) bool {
-		return element.AsObject.GetString("name") == "Chris"
+	array, _ := mapper.AsArray()
+	filteredArr := array.Filter(func(element jogson.JsonMapper) bool {
+		object, err := element.AsObject()
+		assert.NoError(t, err)
+		return object.GetString("name") == "Chris"
 	})
 	assert.Equal(t, 1, filteredArr.Length())
-	assert.Equal(t, "Chris", filteredArr.Elements()[0].AsObject.GetString("name"))
+	assert.Equal(t, "Chris", filteredArr.GetObject(0).GetString("name"))
 }
 
 func TestArrayFilterNull(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonAnyArrayTest)
 	assert.NoError(t, err)
-	filteredArr := mapper.AsArray.FilterNull()
-	assert.Equal(t, 5, mapper.AsArray.Length())
+	array, _ := mapper.AsArray()
+	filteredArr := array.FilterNull()
+	assert.Equal(t, 5, array.Length())
 	assert.Equal(t, 4, filteredArr.Length())
 	filteredArr.ForEach(func(j jogson.JsonMapper) {
-		assert.True(t, !j.IsNull)
+		assert.True(t, !j.IsNull())
 	})
 }
 
 func TestArrayForEach(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonObjectArrayTest)
 	assert.NoError(t, err)
+	array, _ := mapper.AsArray()
 	wasVisited := false
-	mapper.AsArray.ForEach(func(mapper jogson.JsonMapper) {
+	array.ForEach(func(mapper jogson.JsonMapper) {
 		wasVisited = true
 		assert.NotNil(t, mapper)
 	})
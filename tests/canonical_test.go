@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectCanonicalString(t *testing.T) {
+	object, err := jogson.NewObjectFromString(jsonCanonicalTest)
+	assert.NoError(t, err)
+	canonical, err := object.CanonicalString()
+	assert.NoError(t, err)
+	expected := `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`
+	assert.Equal(t, expected, canonical)
+}
+
+func TestCanonicalKeyOrder(t *testing.T) {
+	object, err := jogson.NewObjectFromString(jsonCanonicalSortTest)
+	assert.NoError(t, err)
+	canonical, err := object.CanonicalString()
+	assert.NoError(t, err)
+	expected := "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"ö\":\"Latin Small Letter O With Diaeresis\",\"€\":\"Euro Sign\",\"😀\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}"
+	assert.Equal(t, expected, canonical)
+}
+
+func TestCanonicalNumbers(t *testing.T) {
+	array := jogson.EmptyArray()
+	for _, f := range []float64{0, math.Copysign(0, -1), 1, -1.5, 1e21, 1e20, 123456789012345680000, 1e-6, 1e-7, 5e-324, 1.7976931348623157e308} {
+		array.AddFloat(f)
+	}
+	array.AddInt(15)
+	canonical, err := array.CanonicalString()
+	assert.NoError(t, err)
+	assert.Equal(t, "[0,0,1,-1.5,1e+21,100000000000000000000,123456789012345680000,0.000001,1e-7,5e-324,1.7976931348623157e+308,15]", canonical)
+
+	array.AddFloat(math.NaN())
+	_, err = array.CanonicalString()
+	assert.ErrorIs(t, err, jogson.CanonicalizationErr)
+}
+
+func TestFingerprint(t *testing.T) {
+	object1, err := jogson.NewObjectFromString(`{"b": 1.0, "a": [1, "x", {"d": null, "c": true}]}`)
+	assert.NoError(t, err)
+	object2, err := jogson.NewObjectFromString(`{"a": [1.00, "x", {"c": true, "d": null}], "b": 1}`)
+	assert.NoError(t, err)
+	fingerprint1, err := object1.Fingerprint()
+	assert.NoError(t, err)
+	fingerprint2, err := object2.Fingerprint()
+	assert.NoError(t, err)
+	assert.Equal(t, fingerprint1, fingerprint2)
+
+	hash := sha256.Sum256([]byte(`{"a":[1,"x",{"c":true,"d":null}],"b":1}`))
+	assert.Equal(t, hex.EncodeToString(hash[:]), fingerprint1)
+
+	mapper, err := jogson.NewMapperFromString(`{"a": [1, "x", {"c": true, "d": null}], "b": 1}`)
+	assert.NoError(t, err)
+	mapperFingerprint, err := mapper.Fingerprint()
+	assert.NoError(t, err)
+	assert.Equal(t, fingerprint1, mapperFingerprint)
+}
+
+func TestHMAC(t *testing.T) {
+	array, err := jogson.NewArrayFromString(`[{"b": 2, "a": 1}]`)
+	assert.NoError(t, err)
+	key := []byte("secret")
+	signature, err := array.HMAC(key)
+	assert.NoError(t, err)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(`[{"a":1,"b":2}]`))
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), signature)
+}
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseConverters(t *testing.T) {
+	assert.Equal(t, "http_server_id", jogson.ToSnakeCase("HTTPServerID"))
+	assert.Equal(t, "user_id", jogson.ToSnakeCase("userId"))
+	assert.Equal(t, "second_address2", jogson.ToSnakeCase("secondAddress2"))
+	assert.Equal(t, "userId", jogson.ToCamelCase("user_id"))
+	assert.Equal(t, "httpServerId", jogson.ToCamelCase("HTTPServerID"))
+	assert.Equal(t, "UserId", jogson.ToPascalCase("user-id"))
+	assert.Equal(t, "user-id", jogson.ToKebabCase("UserID"))
+	assert.Equal(t, "USER_ID", jogson.ToScreamingSnakeCase("userId"))
+	assert.Equal(t, "", jogson.ToSnakeCase(""))
+}
+
+func TestCaseConverterAcronyms(t *testing.T) {
+	converter := jogson.NewCaseConverter(jogson.SnakeCase, jogson.CamelCase, "ID", "URL")
+	assert.Equal(t, "userID", converter.Convert("user_id"))
+	assert.Equal(t, "avatarURL", converter.Convert("avatar_url"))
+	assert.Equal(t, "idCard", converter.Convert("id_card"))
+
+	reverse := converter.Reverse()
+	assert.Equal(t, "user_id", reverse.Convert("userID"))
+	assert.Equal(t, "avatar_url", reverse.Convert("avatarURL"))
+
+	pascal := jogson.NewCaseConverter(jogson.KebabCase, jogson.PascalCase, "http")
+	assert.Equal(t, "HTTPServer", pascal.Convert("http-server"))
+}
+
+func TestObjectTransformKeysRecursive(t *testing.T) {
+	object, err := jogson.NewObjectFromString(jsonObjectKeysSnakeCaseTest)
+	assert.NoError(t, err)
+	converter := jogson.NewCaseConverter(jogson.SnakeCase, jogson.CamelCase)
+	camelCase := object.ConvertKeys(converter)
+	assert.ElementsMatch(t, []string{"userId", "firstName", "homeAddress", "childList"}, camelCase.Keys())
+
+	address := camelCase.GetObject("homeAddress")
+	assert.ElementsMatch(t, []string{"streetName", "zipCode"}, address.Keys())
+
+	children := camelCase.GetArray("childList")
+	assert.Equal(t, 4, children.Length())
+	assert.Equal(t, "Rachel", children.GetObject(0).GetString("childName"))
+	assert.True(t, children.GetObject(0).GetBool("isFunny"))
+	assert.Equal(t, 1, children.GetArray(1).GetObject(0).GetInt("nestedKey"))
+	assert.Equal(t, 3, children.GetInt(2))
+
+	original := camelCase.ConvertKeys(converter.Reverse())
+	assert.Equal(t, removeWhiteSpaces(object.String()), removeWhiteSpaces(original.String()))
+	assert.True(t, object.Contains("user_id"))
+}
+
+func TestArrayTransformKeys(t *testing.T) {
+	array, err := jogson.NewArrayFromString(jsonArrayKeysCamelCaseTest)
+	assert.NoError(t, err)
+	snakeCase := array.TransformKeys(jogson.ToSnakeCase)
+	assert.Equal(t, 3, snakeCase.Length())
+	assert.Equal(t, 1, snakeCase.GetObject(0).GetInt("user_id"))
+	assert.Equal(t, "9th Street", snakeCase.GetObject(0).GetObject("home_address").GetString("street_name"))
+	assert.Equal(t, "Sara", snakeCase.GetObject(1).GetArray("child_list").GetObject(0).GetString("child_name"))
+	assert.Nil(t, snakeCase.GetStringN(2))
+}
@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCBORBytes(t *testing.T) {
+	// examples from RFC 8949 appendix A
+	tests := []struct {
+		json     string
+		expected string
+	}{
+		{`0`, "00"},
+		{`23`, "17"},
+		{`24`, "1818"},
+		{`1000`, "1903e8"},
+		{`1000000`, "1a000f4240"},
+		{`-1`, "20"},
+		{`-1000`, "3903e7"},
+		{`100000.0`, "1a000186a0"},
+		{`1.5`, "f93e00"},
+		{`5.960464477539063e-8`, "f90001"},
+		{`0.00006103515625`, "f90400"},
+		{`3.4028234663852886e+38`, "fa7f7fffff"},
+		{`1.1`, "fb3ff199999999999a"},
+		{`-4.1`, "fbc010666666666666"},
+		{`false`, "f4"},
+		{`null`, "f6"},
+		{`"IETF"`, "6449455446"},
+		{`"ü"`, "62c3bc"},
+		{`[1, [2, 3], [4, 5]]`, "8301820203820405"},
+		{`{"a": 1, "b": [2, 3]}`, "a26161016162820203"},
+		{`{"b": 2, "aa": 1}`, "a261620262616101"},
+	}
+	for _, test := range tests {
+		mapper, err := jogson.NewMapperFromString(test.json)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, hex.EncodeToString(mapper.CBORBytes()), test.json)
+	}
+
+	object, err := jogson.NewObjectFromString(`{"name": "Jason", "children": [{"age": 15.5}, null], "nested": {"x": true}}`)
+	assert.NoError(t, err)
+	roundTrip, err := jogson.NewObjectFromCBOR(object.CBORBytes())
+	assert.NoError(t, err)
+	assert.Equal(t, object.String(), roundTrip.String())
+}
+
+func TestNewMapperFromCBOR(t *testing.T) {
+	tests := []struct {
+		cbor     string
+		expected string
+	}{
+		{"1b7fffffffffffffff", `9223372036854775807`},
+		{"1bffffffffffffffff", `18446744073709551615`},
+		{"3b7fffffffffffffff", `-9223372036854775808`},
+		{"3bffffffffffffffff", `-18446744073709551616`},
+		{"c249010000000000000000", `18446744073709551616`},
+		{"c349010000000000000000", `-18446744073709551617`},
+		{"c2420100", `256`},
+		{"c074323031332d30332d32315432303a30343a30305a", `"2013-03-21T20:04:00Z"`},
+		{"c11a514b67b0", `"2013-03-21T20:04:00Z"`},
+		{"c1fb41d452d9ec200000", `"2013-03-21T20:04:00.5Z"`},
+		{"d82076687474703a2f2f7777772e6578616d706c652e636f6d", `"http://www.example.com"`},
+		{"4401020304", `"AQIDBA=="`},
+		{"5f42010243030405ff", `"AQIDBAU="`},
+		{"7f657374726561646d696e67ff", `"streaming"`},
+		{"9f018202039f0405ffff", `[1,[2,3],[4,5]]`},
+		{"bf61610161629f0203ffff", `{"a":1,"b":[2,3]}`},
+		{"a201020304", `{"1":2,"3":4}`},
+		{"f7", `null`},
+		{"f97c00", ""},
+	}
+	for _, test := range tests {
+		data, _ := hex.DecodeString(test.cbor)
+		mapper, err := jogson.NewMapperFromCBOR(data)
+		if test.expected == "" {
+			assert.ErrorIs(t, err, jogson.ParseErr, test.cbor)
+			continue
+		}
+		assert.NoError(t, err, test.cbor)
+		assert.Equal(t, test.expected, mapper.String(), test.cbor)
+	}
+
+	data, _ := hex.DecodeString("a263626967c24901000000000000000064746167730a")
+	object, err := jogson.NewObjectFromCBOR(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "18446744073709551616", object.GetBigInt("big").String())
+	assert.NoError(t, object.LastError)
+	assert.Equal(t, int64(10), object.GetBigInt("tags").Int64())
+	assert.Nil(t, object.GetBigInt("missing"))
+	assert.ErrorIs(t, object.LastError, jogson.KeyNotFoundErr)
+
+	// integers outside the range of int stay numbers and are encoded as they were decoded
+	for _, encoded := range []string{"a1636e65673bffffffffffffffff", "a1636e6567c349010000000000000000"} {
+		data, _ = hex.DecodeString(encoded)
+		object, err = jogson.NewObjectFromCBOR(data)
+		assert.NoError(t, err)
+		assert.True(t, object.Get("neg").IsNumber())
+		assert.NotNil(t, object.GetBigInt("neg"))
+		assert.Equal(t, encoded, hex.EncodeToString(object.CBORBytes()))
+	}
+}
+
+func TestNewMapperFromCBORFails(t *testing.T) {
+	tests := []struct {
+		cbor    string
+		message string
+	}{
+		{"", "parse error: cbor: offset 0: unexpected end of input"},
+		{"1c", "parse error: cbor: offset 0: invalid additional information 28"},
+		{"ff", "parse error: cbor: offset 0: unexpected break"},
+		{"9f01", "parse error: cbor: offset 2: unexpected end of input"},
+		{"0102", "parse error: cbor: offset 1: unexpected data after the top-level value"},
+		{"a1800102", "parse error: cbor: offset 1: map key of type array cannot be represented in JSON"},
+		{"c201", "parse error: cbor: offset 1: bignum must be a byte string"},
+		{"c06161", "parse error: cbor: offset 1: invalid date/time 'a'"},
+		{"5f6161ff", "parse error: cbor: offset 1: invalid chunk of indefinite-length string"},
+		{"f0", "parse error: cbor: offset 0: unsupported simple value 16"},
+		{"9bffffffffffffffff", "parse error: cbor: offset 9: unexpected end of input"},
+	}
+	for _, test := range tests {
+		data, _ := hex.DecodeString(test.cbor)
+		_, err := jogson.NewMapperFromCBOR(data)
+		assert.EqualError(t, err, test.message, test.cbor)
+	}
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+const jsonCSVTest = `[
+	{"name": "Jason", "age": 43, "address": {"city": "Berlin", "zip": "01067"}, "tags": ["a", "b"]},
+	{"name": "Rachel, Jr.", "age": 15.5, "address": null, "is_funny": false}
+]`
+
+func TestArrayToCSV(t *testing.T) {
+	array, err := jogson.NewArrayFromString(jsonCSVTest)
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = array.ToCSV(&buf, jogson.CSVOptions{Null: "NULL"})
+	assert.NoError(t, err)
+	expected := `address,age,is_funny,name,tags
+"{""city"":""Berlin"",""zip"":""01067""}",43,,Jason,"[""a"",""b""]"
+NULL,15.5,false,"Rachel, Jr.",
+`
+	assert.Equal(t, expected, buf.String())
+
+	buf.Reset()
+	err = array.ToCSV(&buf, jogson.CSVOptions{Flatten: true, Comma: '\t'})
+	assert.NoError(t, err)
+	expected = "address\taddress.city\taddress.zip\tage\tis_funny\tname\ttags.0\ttags.1\n" +
+		"\tBerlin\t01067\t43\t\tJason\ta\tb\n" +
+		"\t\t\t15.5\tfalse\tRachel, Jr.\t\t\n"
+	assert.Equal(t, expected, buf.String())
+
+	buf.Reset()
+	err = array.ToCSV(&buf, jogson.CSVOptions{Columns: []string{"name", "age"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "name,age\nJason,43\n\"Rachel, Jr.\",15.5\n", buf.String())
+
+	array, err = jogson.NewArrayFromString(`[{"a": 1}, 2]`)
+	assert.NoError(t, err)
+	err = array.ToCSV(&buf, jogson.CSVOptions{})
+	assert.ErrorIs(t, err, jogson.TypeConversionErr)
+}
+
+func TestNewArrayFromCSV(t *testing.T) {
+	csvData := "name,age,zip,is_funny,tags,address\nJason,43,01067,true,\"[\"\"a\"\"]\",\nRachel,15.5,,false,[],\"{\"\"city\"\": \"\"Berlin\"\"}\"\n"
+	array, err := jogson.NewArrayFromCSV(strings.NewReader(csvData), jogson.CSVOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, array.Length())
+	assert.Equal(t, "43", array.GetObject(0).GetString("age"))
+	assert.Equal(t, "", array.GetObject(0).GetString("address"))
+
+	array, err = jogson.NewArrayFromCSV(strings.NewReader(csvData), jogson.CSVOptions{InferTypes: true})
+	assert.NoError(t, err)
+	jason := array.GetObject(0)
+	assert.Equal(t, 43, jason.GetInt("age"))
+	assert.Equal(t, "01067", jason.GetString("zip"))
+	assert.True(t, jason.GetBool("is_funny"))
+	assert.Equal(t, []string{"a"}, jason.GetArray("tags").AsStringArray())
+	assert.True(t, jason.Get("address").IsNull())
+	rachel := array.GetObject(1)
+	assert.Equal(t, 15.5, rachel.GetFloat("age"))
+	assert.Equal(t, "Berlin", rachel.GetObject("address").GetString("city"))
+
+	tsvData := "name\taddress.city\ttags.0\ttags.1\nJason\tBerlin\ta\tb\n"
+	array, err = jogson.NewArrayFromCSV(strings.NewReader(tsvData), jogson.CSVOptions{Comma: '\t', Flatten: true})
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"address":{"city":"Berlin"},"name":"Jason","tags":["a","b"]}]`, array.String())
+
+	_, err = jogson.NewArrayFromCSV(strings.NewReader("a,a.b\n1,2\n"), jogson.CSVOptions{Flatten: true})
+	assert.ErrorIs(t, err, jogson.InvalidPathErr)
+	_, err = jogson.NewArrayFromCSV(strings.NewReader("a,b,a\n1,2,3\n"), jogson.CSVOptions{})
+	assert.ErrorIs(t, err, jogson.InvalidPathErr)
+	_, err = jogson.NewArrayFromCSV(strings.NewReader("a,b,a\n1,2,3\n"), jogson.CSVOptions{Flatten: true})
+	assert.ErrorIs(t, err, jogson.InvalidPathErr)
+	array, err = jogson.NewArrayFromCSV(strings.NewReader("a,a.b\n1,2\n"), jogson.CSVOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"a":"1","a.b":"2"}]`, array.String())
+	_, err = jogson.NewArrayFromCSV(strings.NewReader("a,a-b,a.b\n1,2,3\n"), jogson.CSVOptions{Flatten: true})
+	assert.ErrorIs(t, err, jogson.InvalidPathErr)
+	_, err = jogson.NewArrayFromCSV(strings.NewReader("a.b.,a.b.0.c\n1,2\n"), jogson.CSVOptions{Flatten: true})
+	assert.ErrorIs(t, err, jogson.InvalidPathErr)
+	array, err = jogson.NewArrayFromCSV(strings.NewReader("0,1\na,b\n"), jogson.CSVOptions{Flatten: true})
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"0":"a","1":"b"}]`, array.String())
+	_, err = jogson.NewArrayFromCSV(strings.NewReader("a,b\n1,2,3\n"), jogson.CSVOptions{})
+	assert.ErrorIs(t, err, jogson.ParseErr)
+	assert.Equal(t, "parse error: 2:1: wrong number of fields", err.Error())
+
+	array, err = jogson.NewArrayFromCSV(strings.NewReader(""), jogson.CSVOptions{})
+	assert.NoError(t, err)
+	assert.True(t, array.IsEmpty())
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	array, err := jogson.NewArrayFromString(`[{"a": {"b": [1, 2]}, "c": "x"}, {"a": {"b": [3, 4]}, "c": null}]`)
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	opts := jogson.CSVOptions{Flatten: true, InferTypes: true, Null: "null"}
+	err = array.ToCSV(&buf, opts)
+	assert.NoError(t, err)
+	roundTrip, err := jogson.NewArrayFromCSV(&buf, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, array.String(), roundTrip.String())
+}
@@ -0,0 +1,167 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentSet(t *testing.T) {
+	doc, err := jogson.NewDocument([]byte(jsonDocumentTest))
+	assert.NoError(t, err)
+
+	assert.NoError(t, doc.Set(jogson.Path{"version"}, "1.3.0"))
+	assert.NoError(t, doc.Set(jogson.Path{"database", "pool"}, 20))
+	assert.NoError(t, doc.Set(jogson.Path{"database", "user"}, "admin"))
+	assert.NoError(t, doc.Set(jogson.Path{"ports", "2"}, 8082))
+	assert.NoError(t, doc.Set(jogson.Path{"tags"}, []any{"a"}))
+	expected := `{
+    // service settings
+    "name": "api",
+    "version": "1.3.0", // bumped by CI
+    "ports": [8080, 8081, 8082],
+    "database": {
+        "host": "localhost",
+        "pool": 20,
+        "user": "admin"
+    },
+    "tags": [
+        "a"
+    ]
+}
+`
+	assert.Equal(t, expected, doc.String())
+
+	mapper, err := doc.Mapper()
+	assert.NoError(t, err)
+	object, err := mapper.AsObject()
+	assert.NoError(t, err)
+	assert.Equal(t, 20, object.GetObject("database").GetInt("pool"))
+
+	assert.ErrorIs(t, doc.Set(jogson.Path{"missing", "key"}, 1), jogson.KeyNotFoundErr)
+	assert.ErrorIs(t, doc.Set(jogson.Path{"ports", "5"}, 1), jogson.IndexOutOfRangeErr)
+	assert.ErrorIs(t, doc.Set(jogson.Path{"name", "key"}, 1), jogson.KeyNotFoundErr)
+	assert.Equal(t, expected, doc.String())
+}
+
+func TestDocumentSetEmpty(t *testing.T) {
+	doc, err := jogson.NewDocument([]byte(`{"a": {}, "b": [
+	]}`))
+	assert.NoError(t, err)
+	assert.NoError(t, doc.Set(jogson.Path{"a", "x"}, true))
+	assert.NoError(t, doc.Set(jogson.Path{"b", "0"}, nil))
+	assert.Equal(t, "{\"a\": {\"x\": true}, \"b\": [\n\tnull\n]}", doc.String())
+}
+
+func TestDocumentRemove(t *testing.T) {
+	doc, err := jogson.NewDocument([]byte(jsonDocumentTest))
+	assert.NoError(t, err)
+
+	assert.NoError(t, doc.Remove(jogson.Path{"version"}))
+	assert.NoError(t, doc.Remove(jogson.Path{"ports", "1"}))
+	assert.NoError(t, doc.Remove(jogson.Path{"database", "pool"}))
+	expected := `{
+    // service settings
+    "name": "api",
+    "ports": [8080],
+    "database": {
+        "host": "localhost"
+    }
+}
+`
+	assert.Equal(t, expected, doc.String())
+
+	assert.NoError(t, doc.Remove(jogson.Path{"ports", "0"}))
+	assert.Contains(t, doc.String(), `"ports": [],`)
+	assert.ErrorIs(t, doc.Remove(jogson.Path{"version"}), jogson.KeyNotFoundErr)
+}
+
+func TestDocumentWriteToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.jsonc")
+	assert.NoError(t, os.WriteFile(path, []byte(jsonDocumentTest), 0600))
+	doc, err := jogson.NewDocumentFromFile(path)
+	assert.NoError(t, err)
+	assert.NoError(t, doc.Set(jogson.Path{"name"}, "web"))
+	assert.NoError(t, doc.WriteToFile(path))
+
+	written, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, doc.String(), string(written))
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	// the file is replaced by renaming a temporary file, which is not left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	_, err = jogson.NewDocumentFromFile("files/test_invalid_object.json")
+	assert.ErrorIs(t, err, jogson.ParseErr)
+}
+
+func TestDocumentFromCompressedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.jsonc.gz")
+	assert.NoError(t, os.WriteFile(path, compress(t, "gzip", jsonDocumentTest), 0644))
+	doc, err := jogson.NewDocumentFromFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, jsonDocumentTest, doc.String())
+
+	newPath := filepath.Join(t.TempDir(), "config.jsonc")
+	assert.NoError(t, doc.WriteToFile(newPath))
+	info, err := os.Stat(newPath)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+}
+
+func TestDocumentEditKeepsComments(t *testing.T) {
+	doc, err := jogson.NewDocument([]byte("{\"a\": 1, // about b\n \"b\": 2}"))
+	assert.NoError(t, err)
+	assert.NoError(t, doc.Remove(jogson.Path{"a"}))
+	assert.Equal(t, "{// about b\n \"b\": 2}", doc.String())
+
+	source := `{
+    "a": 1, // about a
+    // about b
+    "b": 2, // b
+    "c": 3 // about c
+}`
+	doc, err = jogson.NewDocument([]byte(source))
+	assert.NoError(t, err)
+	assert.NoError(t, doc.Remove(jogson.Path{"c"}))
+	assert.Equal(t, `{
+    "a": 1, // about a
+    // about b
+    "b": 2 // b
+}`, doc.String())
+	assert.NoError(t, doc.Remove(jogson.Path{"a"}))
+	assert.Equal(t, `{
+    // about b
+    "b": 2 // b
+}`, doc.String())
+
+	assert.NoError(t, doc.Set(jogson.Path{"d"}, 4))
+	assert.Equal(t, `{
+    // about b
+    "b": 2, // b
+    "d": 4
+}`, doc.String())
+	assert.NoError(t, doc.Set(jogson.Path{"e"}, 5))
+	assert.Equal(t, `{
+    // about b
+    "b": 2, // b
+    "d": 4,
+    "e": 5
+}`, doc.String())
+
+	doc, err = jogson.NewDocument([]byte("[\n  1, /* one */\n  2, // two\n]"))
+	assert.NoError(t, err)
+	assert.NoError(t, doc.Set(jogson.Path{"2"}, 3))
+	assert.Equal(t, "[\n  1, /* one */\n  2, // two\n  3\n]", doc.String())
+	assert.NoError(t, doc.Remove(jogson.Path{"1"}))
+	assert.Equal(t, "[\n  1, /* one */\n  3\n]", doc.String())
+	assert.NoError(t, doc.Remove(jogson.Path{"1"}))
+	assert.Equal(t, "[\n  1 /* one */\n]", doc.String())
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+var testEnviron = []string{
+	"APP_DB__HOST=localhost",
+	"APP_DB__PORT=5432",
+	"APP_DEBUG=true",
+	"APP_HOSTS__1=b",
+	"APP_HOSTS__0=a",
+	"APP_FEATURES=[\"x\",\"y\"]",
+	"APP_ZIP=01067",
+	"APP___IGNORED=1",
+	"OTHER=1",
+}
+
+func TestNewObjectFromEnv(t *testing.T) {
+	obj, err := jogson.NewObjectFromEnv("APP_", jogson.EnvOptions{Environ: testEnviron})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"db":{"host":"localhost","port":"5432"},"debug":"true","features":"[\"x\",\"y\"]","hosts":["a","b"],"zip":"01067"}`, obj.String())
+
+	obj, err = jogson.NewObjectFromEnv("APP_", jogson.EnvOptions{Environ: testEnviron, InferTypes: true, JSONValues: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 5432, obj.GetObject("db").GetInt("port"))
+	assert.True(t, obj.GetBool("debug"))
+	assert.Equal(t, "01067", obj.GetString("zip"))
+	assert.Equal(t, []string{"x", "y"}, obj.GetArray("features").AsStringArray())
+
+	obj, err = jogson.NewObjectFromEnv("APP_", jogson.EnvOptions{Environ: []string{"APP_DB_HOST=x"}, Separator: "_"})
+	assert.NoError(t, err)
+	assert.Equal(t, "x", obj.GetObject("db").GetString("host"))
+
+	_, err = jogson.NewObjectFromEnv("APP_", jogson.EnvOptions{Environ: []string{"APP_DB=x", "APP_DB__HOST=y"}})
+	assert.ErrorIs(t, err, jogson.InvalidPathErr)
+
+	// top-level keys that are indices stay keys of the object
+	obj, err = jogson.NewObjectFromEnv("APP_", jogson.EnvOptions{Environ: []string{"APP_0=x"}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"0":"x"}`, obj.String())
+}
+
+func TestNewObjectFromEnvProcess(t *testing.T) {
+	t.Setenv("JOGSON_TEST_NAME", "Jason")
+	obj, err := jogson.NewObjectFromEnv("JOGSON_TEST_", jogson.EnvOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Jason", obj.GetString("name"))
+}
+
+func TestObjectToEnv(t *testing.T) {
+	obj, err := jogson.NewObjectFromString(`{"db": {"host": "localhost", "port": 5432}, "hosts": ["a", "b"], "log-level": null, "tags": []}`)
+	assert.NoError(t, err)
+	expected := []string{
+		"APP_DB__HOST=localhost",
+		"APP_DB__PORT=5432",
+		"APP_HOSTS__0=a",
+		"APP_HOSTS__1=b",
+		"APP_LOG_LEVEL=",
+		"APP_TAGS=[]",
+	}
+	assert.Equal(t, expected, obj.ToEnv("APP_"))
+	assert.Equal(t, "APP_DB.HOST=localhost", obj.ToEnvWithOptions("APP_", jogson.EnvOptions{Separator: "."})[0])
+
+	roundTrip, err := jogson.NewObjectFromEnv("APP_", jogson.EnvOptions{Environ: expected, InferTypes: true, JSONValues: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 5432, roundTrip.GetObject("db").GetInt("port"))
+	assert.Equal(t, 0, roundTrip.GetArray("tags").Length())
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("JOGSON_TEST_HOST", "db.local")
+	t.Setenv("JOGSON_TEST_EMPTY", "")
+	obj, err := jogson.NewObjectFromString(`{"url": "postgres://${JOGSON_TEST_HOST}:${JOGSON_TEST_PORT:-5432}/app", "empty": "${JOGSON_TEST_EMPTY:-x}${JOGSON_TEST_EMPTY}", "nested": [{"v": "$HOME ${JOGSON_TEST_UNSET}"}], "n": 1}`)
+	assert.NoError(t, err)
+
+	expanded := obj.ExpandEnv()
+	assert.Equal(t, "postgres://db.local:5432/app", expanded.GetString("url"))
+	assert.Equal(t, "x", expanded.GetString("empty"))
+	assert.Equal(t, "$HOME ", expanded.GetArray("nested").GetObject(0).GetString("v"))
+	assert.Equal(t, 1, expanded.GetInt("n"))
+	assert.Equal(t, "postgres://${JOGSON_TEST_HOST}:${JOGSON_TEST_PORT:-5432}/app", obj.GetString("url"))
+
+	array, err := jogson.NewArrayFromString(`["${JOGSON_TEST_HOST}"]`)
+	assert.NoError(t, err)
+	assert.Equal(t, `["db.local"]`, array.ExpandEnv().String())
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustMapper(t *testing.T, s string) jogson.JsonMapper {
+	mapper, err := jogson.NewMapperFromString(s)
+	assert.NoError(t, err)
+	return mapper
+}
+
+func TestEqual(t *testing.T) {
+	a := mustMapper(t, `{"a": 1, "b": [1.0, "x", null], "c": {"d": true}}`)
+	b := mustMapper(t, `{"c": {"d": true}, "b": [1, "x", null], "a": 1.0}`)
+	assert.True(t, jogson.Equal(a, b, jogson.EqualOptions{}))
+
+	c := mustMapper(t, `{"c": {"d": false}, "b": [1, "x", null], "a": 1}`)
+	assert.False(t, jogson.Equal(a, c, jogson.EqualOptions{}))
+	assert.False(t, jogson.Equal(a, mustMapper(t, `{"a": 1}`), jogson.EqualOptions{}))
+	assert.False(t, jogson.Equal(mustMapper(t, `[1]`), mustMapper(t, `{"a": 1}`), jogson.EqualOptions{}))
+	assert.False(t, jogson.Equal(mustMapper(t, `[null]`), mustMapper(t, `[0]`), jogson.EqualOptions{}))
+	assert.True(t, jogson.Equal(mustMapper(t, `"s"`), mustMapper(t, `"s"`), jogson.EqualOptions{}))
+}
+
+func TestEqualWithOptions(t *testing.T) {
+	a := mustMapper(t, `{"values": [1, 2, 3], "meta": {"id": 5, "time": "now"}, "f": 1.0001}`)
+	b := mustMapper(t, `{"values": [3, 1, 2], "meta": {"id": 6}, "f": 1.0002}`)
+	assert.False(t, jogson.Equal(a, b, jogson.EqualOptions{}))
+	opts := jogson.EqualOptions{
+		IgnoreArrayOrder: true,
+		FloatTolerance:   0.001,
+		IgnorePaths:      []string{"$.meta.time", "$..id"},
+	}
+	assert.True(t, jogson.Equal(a, b, opts))
+
+	opts.FloatTolerance = 0
+	assert.False(t, jogson.Equal(a, b, opts))
+}
+
+func TestEqualLargeIntegers(t *testing.T) {
+	mustValue := func(v any) jogson.JsonMapper {
+		mapper, err := jogson.NewMapperFromValue(v)
+		assert.NoError(t, err)
+		return mapper
+	}
+	// 2^53 + 1 and 2^53 are the same float64
+	assert.False(t, jogson.Equal(mustValue(9007199254740993), mustValue(9007199254740992), jogson.EqualOptions{}))
+	assert.True(t, jogson.Equal(mustValue(9007199254740993), mustValue(int64(9007199254740993)), jogson.EqualOptions{}))
+	assert.True(t, jogson.Equal(mustValue(9007199254740993), mustValue(9007199254740992), jogson.EqualOptions{FloatTolerance: 1}))
+
+	maxUint := mustValue(uint64(math.MaxUint64))
+	assert.True(t, jogson.Equal(maxUint, mustValue(new(big.Int).SetUint64(math.MaxUint64)), jogson.EqualOptions{}))
+	assert.False(t, jogson.Equal(maxUint, mustValue(uint64(math.MaxUint64-1)), jogson.EqualOptions{}))
+	// floats are compared as floats
+	assert.True(t, jogson.Equal(maxUint, mustValue(float64(math.MaxUint64)), jogson.EqualOptions{}))
+	assert.True(t, jogson.Equal(mustValue(1), mustValue(1.0), jogson.EqualOptions{}))
+}
+
+func TestEqualObjectsAndArrays(t *testing.T) {
+	obj := jogson.EmptyObject()
+	obj.AddInt("age", 15)
+	obj.AddIntArray("numbers", []int{1, 2})
+	parsed, err := jogson.NewObjectFromString(`{"age": 15.0, "numbers": [1, 2]}`)
+	assert.NoError(t, err)
+	a, err := jogson.NewMapperFromValue(obj)
+	assert.NoError(t, err)
+	b, err := jogson.NewMapperFromValue(parsed)
+	assert.NoError(t, err)
+	assert.True(t, jogson.Equal(a, b, jogson.EqualOptions{}))
+}
+
+func TestContains(t *testing.T) {
+	doc := mustMapper(t, `{"name": "Jason", "age": 15, "children": [{"name": "Rachel", "age": 15}, {"name": "Sara", "age": 19}], "tags": ["a", "b", "c"]}`)
+	assert.True(t, jogson.Contains(doc, mustMapper(t, `{"name": "Jason"}`), jogson.EqualOptions{}))
+	assert.True(t, jogson.Contains(doc, mustMapper(t, `{"children": [{"name": "Sara"}]}`), jogson.EqualOptions{}))
+	assert.True(t, jogson.Contains(doc, mustMapper(t, `{"tags": ["a", "c"]}`), jogson.EqualOptions{}))
+	assert.False(t, jogson.Contains(doc, mustMapper(t, `{"tags": ["c", "a"]}`), jogson.EqualOptions{}))
+	assert.True(t, jogson.Contains(doc, mustMapper(t, `{"tags": ["c", "a"]}`), jogson.EqualOptions{IgnoreArrayOrder: true}))
+	assert.False(t, jogson.Contains(doc, mustMapper(t, `{"tags": ["a", "a"]}`), jogson.EqualOptions{IgnoreArrayOrder: true}))
+	assert.False(t, jogson.Contains(doc, mustMapper(t, `{"name": "Chris"}`), jogson.EqualOptions{}))
+	assert.False(t, jogson.Contains(doc, mustMapper(t, `{"height": 1.8}`), jogson.EqualOptions{}))
+	assert.True(t, jogson.Contains(doc, mustMapper(t, `{"age": 15.0}`), jogson.EqualOptions{}))
+	assert.True(t, jogson.Contains(doc, mustMapper(t, `{}`), jogson.EqualOptions{}))
+}
+
+func TestUnorderedMatching(t *testing.T) {
+	unordered := jogson.EqualOptions{IgnoreArrayOrder: true}
+	doc := mustMapper(t, `[{"a": 1, "b": 2}, {"a": 1}]`)
+	assert.True(t, jogson.Contains(doc, mustMapper(t, `[{"a": 1}, {"a": 1, "b": 2}]`), unordered))
+	assert.False(t, jogson.Contains(doc, mustMapper(t, `[{"a": 1, "b": 2}, {"b": 2}]`), unordered))
+
+	tolerance := jogson.EqualOptions{IgnoreArrayOrder: true, FloatTolerance: 0.15}
+	assert.True(t, jogson.Equal(mustMapper(t, `[1.0, 1.2]`), mustMapper(t, `[1.1, 1.0]`), tolerance))
+	assert.False(t, jogson.Equal(mustMapper(t, `[1.0, 1.4]`), mustMapper(t, `[1.1, 1.0]`), tolerance))
+}
+
+func TestContainsIgnorePathsUseDocumentIndex(t *testing.T) {
+	doc := mustMapper(t, `{"a": [{"id": 1, "v": "x"}, {"id": 2, "v": "y"}]}`)
+	subset := mustMapper(t, `{"a": [{"id": 2, "v": "changed"}]}`)
+	assert.True(t, jogson.Contains(doc, subset, jogson.EqualOptions{IgnorePaths: []string{"$.a[1].v"}}))
+	assert.False(t, jogson.Contains(doc, subset, jogson.EqualOptions{IgnorePaths: []string{"$.a[0].v"}}))
+	assert.True(t, jogson.Contains(doc, subset, jogson.EqualOptions{IgnorePaths: []string{"$.a[1].v"}, IgnoreArrayOrder: true}))
+}
@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractJSON(t *testing.T) {
+	text := `2024-10-06T17:59:44Z [INFO] request {"method": "GET", "path": "/users"} took 5ms
+2024-10-06T17:59:45Z [WARN] retrying [1, 2, {"id": 3}] {broken: json}
+` + "```json\n{\"name\": \"Jason\"}\n```"
+	found := jogson.ExtractJSONFromString(text)
+	assert.Len(t, found, 3)
+
+	assert.Equal(t, `{"method":"GET","path":"/users"}`, found[0].Mapper.String())
+	assert.Equal(t, `{"method": "GET", "path": "/users"}`, text[found[0].Start:found[0].End])
+	assert.True(t, found[1].Mapper.IsArray())
+	assert.Equal(t, `[1, 2, {"id": 3}]`, text[found[1].Start:found[1].End])
+	object, err := found[2].Mapper.AsObject()
+	assert.NoError(t, err)
+	assert.Equal(t, "Jason", object.GetString("name"))
+
+	assert.Empty(t, jogson.ExtractJSONFromString("no json [here] {or: here}"))
+	assert.Empty(t, jogson.ExtractJSON(nil))
+}
+
+func TestExtractJSONNested(t *testing.T) {
+	found := jogson.ExtractJSONFromString(`[INFO] {"message": "a ] in a string", "ids": [1]} "quoted" {"a": 1}`)
+	assert.Len(t, found, 2)
+	assert.Equal(t, `{"ids":[1],"message":"a ] in a string"}`, found[0].Mapper.String())
+	assert.Equal(t, `{"a":1}`, found[1].Mapper.String())
+
+	// a bracket that is never closed does not hide the JSON after it
+	found = jogson.ExtractJSONFromString(`[ {"a": 1} {"b": 2]`)
+	assert.Len(t, found, 1)
+	assert.Equal(t, `{"a":1}`, found[0].Mapper.String())
+}
+
+func TestExtractJSONLargeInput(t *testing.T) {
+	n := 200000
+	inputs := []string{
+		strings.Repeat("[", n),
+		strings.Repeat("{", n),
+		strings.Repeat("[", n) + strings.Repeat("]", n),
+		strings.Repeat(`[{"a":`, n) + "1",
+		strings.Repeat("[1,", n) + `{"b": 2}`,
+	}
+	for _, input := range inputs {
+		start := time.Now()
+		found := jogson.ExtractJSONFromString(input)
+		assert.Less(t, time.Since(start), 2*time.Second)
+		assert.LessOrEqual(t, len(found), 1)
+	}
+	found := jogson.ExtractJSONFromString(strings.Repeat("[1,", n) + `{"b": 2}`)
+	assert.Len(t, found, 1)
+	assert.Equal(t, `{"b":2}`, found[0].Mapper.String())
+}
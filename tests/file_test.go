@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func compress(t *testing.T, format string, data string) []byte {
+	var buf bytes.Buffer
+	var writer io.WriteCloser
+	switch format {
+	case "gzip":
+		writer = gzip.NewWriter(&buf)
+	case "zlib":
+		writer = zlib.NewWriter(&buf)
+	default:
+		var err error
+		writer, err = flate.NewWriter(&buf, flate.DefaultCompression)
+		assert.NoError(t, err)
+	}
+	_, err := writer.Write([]byte(data))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func TestReadCompressedFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"object.json.gz":  compress(t, "gzip", `{"name": "Jason"}`),
+		"object.gzipped":  compress(t, "gzip", `{"name": "Jason"}`),
+		"object.json.zz":  compress(t, "zlib", `{"name": "Jason"}`),
+		"object.zlibbed":  compress(t, "zlib", `{"name": "Jason"}`),
+		"object.deflate":  compress(t, "deflate", `{"name": "Jason"}`),
+		"object.json":     []byte(`{"name": "Jason"}`),
+		"array.json.gz":   compress(t, "gzip", `[1, 2, 3]`),
+		"corrupt.json.gz": []byte(`{"name": "Jason"}`),
+	}
+	for name, data := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0644))
+	}
+
+	for _, name := range []string{"object.json.gz", "object.gzipped", "object.json.zz", "object.zlibbed", "object.deflate", "object.json"} {
+		path := filepath.Join(dir, name)
+		object, err := jogson.NewObjectFromFile(path)
+		assert.NoError(t, err, name)
+		assert.Equal(t, "Jason", object.GetString("name"), name)
+
+		mapper, err := jogson.NewMapperFromFile(path)
+		assert.NoError(t, err, name)
+		assert.True(t, mapper.IsObject(), name)
+
+		object, err = jogson.NewObjectFromFileWithOptions(path, jogson.ParseOptions{})
+		assert.NoError(t, err, name)
+		assert.Equal(t, "Jason", object.GetString("name"), name)
+	}
+
+	array, err := jogson.NewArrayFromFile(filepath.Join(dir, "array.json.gz"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, array.Length())
+
+	_, err = jogson.NewObjectFromFile(filepath.Join(dir, "corrupt.json.gz"))
+	assert.ErrorIs(t, err, jogson.ParseErr)
+	var parseErr *jogson.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, filepath.Join(dir, "corrupt.json.gz"), parseErr.Path)
+}
+
+func TestReadCompressedFileMaxInputSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "large.json.gz")
+	large := `["` + strings.Repeat("a", 10000) + `"]`
+	assert.NoError(t, os.WriteFile(path, compress(t, "gzip", large), 0644))
+
+	_, err := jogson.NewArrayFromFileWithOptions(path, jogson.ParseOptions{MaxInputSize: 1000})
+	assert.ErrorIs(t, err, jogson.InputTooLargeErr)
+	assert.ErrorIs(t, err, jogson.ParseErr)
+	assert.Contains(t, err.Error(), "decompressed input exceeds the limit of 1000 bytes")
+
+	array, err := jogson.NewArrayFromFileWithOptions(path, jogson.ParseOptions{MaxInputSize: 20000})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, array.Length())
+}
+
+func TestWriteToFile(t *testing.T) {
+	dir := t.TempDir()
+	object, err := jogson.NewObjectFromString(`{"name": "Jason", "tags": ["a"]}`)
+	assert.NoError(t, err)
+
+	path := filepath.Join(dir, "object.json")
+	assert.NoError(t, object.WriteToFile(path, jogson.WriteOptions{}))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"Jason","tags":["a"]}`, string(data))
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+
+	// existing files keep their permissions
+	assert.NoError(t, os.Chmod(path, 0600))
+	assert.NoError(t, object.WriteToFile(path, jogson.WriteOptions{Pretty: true, Perm: 0666}))
+	data, err = os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, object.PrettyString(), string(data))
+	info, err = os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	gzPath := filepath.Join(dir, "object.json.gz")
+	assert.NoError(t, object.WriteToFile(gzPath, jogson.WriteOptions{}))
+	data, err = os.ReadFile(gzPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x1f, 0x8b}, data[:2])
+	readBack, err := jogson.NewObjectFromFile(gzPath)
+	assert.NoError(t, err)
+	assert.Equal(t, object.String(), readBack.String())
+
+	array, err := jogson.NewArrayFromString(`[1, 2]`)
+	assert.NoError(t, err)
+	arrayPath := filepath.Join(dir, "array.bin")
+	assert.NoError(t, array.WriteToFile(arrayPath, jogson.WriteOptions{Gzip: true}))
+	mapper, err := jogson.NewMapperFromFile(arrayPath)
+	assert.NoError(t, err)
+	assert.NoError(t, mapper.WriteToFile(filepath.Join(dir, "mapper.json"), jogson.WriteOptions{}))
+	data, err = os.ReadFile(filepath.Join(dir, "mapper.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,2]`, string(data))
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 4)
+
+	err = object.WriteToFile(filepath.Join(dir, "missing", "object.json"), jogson.WriteOptions{})
+	assert.Error(t, err)
+}
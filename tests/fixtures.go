@@ -38,3 +38,35 @@ const jsonOnlyBoolTest = `true`
 const jsonEmptyArrayTest = `[]`
 const jsonEmptyObjectTest = `{}`
 const jsonOnlyNullTest = `null`
+const jsonObjectKeysSnakeCaseTest = `{"user_id": 1, "first_name": "Jason", "home_address": {"street_name": "9th Street", "zip_code": null}, "child_list": [{"child_name": "Rachel", "is_funny": true}, [{"nested_key": 1}], 3, null]}`
+const jsonArrayKeysCamelCaseTest = `[{"userId": 1, "homeAddress": {"streetName": "9th Street"}}, {"userId": 2, "childList": [{"childName": "Sara"}]}, null]`
+const jsonObjectWalkTest = `{"name": "Jason", "address": null, "children": [{"name": "Rachel", "age": 15}, {"name": "Sara", "age": 19}], "a/b": {"c~d": true}}`
+const jsonObjectRedactTest = `{"user": "jason", "Password": "secret", "auth": {"access_token": "abc", "refresh_token": "def"}, "cards": [{"number": "4111111111111111", "cvv": 123}, {"number": "5500000000000004", "cvv": null}], "credentials": {"key": "value"}}`
+const jsonCanonicalTest = `{"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001], "string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/", "literals": [null, true, false]}`
+const jsonCanonicalSortTest = `{"\u20ac": "Euro Sign", "\r": "Carriage Return", "\ufb33": "Hebrew Letter Dalet With Dagesh", "1": "One", "\ud83d\ude00": "Emoji: Grinning Face", "\u0080": "Control", "\u00f6": "Latin Small Letter O With Diaeresis"}`
+const jsonMissingCommaTest = "{\n  \"name\": \"Jason\",\n  \"age\": 15,,\n  \"address\": null\n}"
+const jsonObjectPositionsTest = "{\n  \"name\": \"Jason\",\n  \"children\": [\n    {\"name\": \"Rachel\", \"age\": 15},\n    {\"name\": \"Sara\", \"age\": -1}\n  ],\n  \"address\": null\n}"
+const jsonJSON5Test = `// JSON5 example
+{
+  unquoted: 'and you can quote me on that',
+  singleQuotes: 'I can use "double quotes" here',
+  lineBreaks: "Look, Mom! \
+No \\n's!",
+  hexadecimal: 0xdecaf,
+  leadingDecimalPoint: .8675309, andTrailing: 8675309.,
+  positiveSign: +1,
+  trailingComma: 'in objects', andIn: ['arrays',],
+  "backwardsCompatible": "with JSON",
+  escapes: '\x41B\'',
+}`
+const jsonDocumentTest = `{
+    // service settings
+    "name": "api",
+    "version": "1.2.0", // bumped by CI
+    "ports": [8080, 8081],
+    "database": {
+        "host": "localhost",
+        "pool": 10
+    }
+}
+`
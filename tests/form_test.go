@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewObjectFromForm(t *testing.T) {
+	body := "name=Jason&age=43&user[address][city]=Berlin&user[address][zip]=01067&tags[]=a&tags[]=b&ids=1&ids=2&q=a+b%26c"
+	obj, err := jogson.NewObjectFromForm(body, jogson.FormOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Jason", obj.GetString("name"))
+	assert.Equal(t, "43", obj.GetString("age"))
+	assert.Equal(t, "a b&c", obj.GetString("q"))
+	assert.Equal(t, "Berlin", obj.GetObject("user").GetObject("address").GetString("city"))
+	assert.Equal(t, []string{"a", "b"}, obj.GetArray("tags").AsStringArray())
+	assert.Equal(t, []string{"1", "2"}, obj.GetArray("ids").AsStringArray())
+
+	obj, err = jogson.NewObjectFromForm(body, jogson.FormOptions{InferTypes: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 43, obj.GetInt("age"))
+	assert.Equal(t, "01067", obj.GetObject("user").GetObject("address").GetString("zip"))
+	assert.Equal(t, []int{1, 2}, obj.GetArray("ids").AsIntArray())
+
+	obj, err = jogson.NewObjectFromForm("items[1][id]=b&items[0][id]=a&a[b=1", jogson.FormOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, obj.GetArray("items").Length())
+	assert.Equal(t, "a", obj.GetArray("items").GetObject(0).GetString("id"))
+	assert.Equal(t, "1", obj.GetString("a[b"))
+
+	_, err = jogson.NewObjectFromForm("a=1&a[b]=2", jogson.FormOptions{})
+	assert.ErrorIs(t, err, jogson.InvalidPathErr)
+	_, err = jogson.NewObjectFromForm("a=%zz", jogson.FormOptions{})
+	assert.ErrorIs(t, err, jogson.ParseErr)
+}
+
+func TestNewObjectFromValuesDotNotation(t *testing.T) {
+	values := url.Values{"user.name": {"Jason"}, "user.tags.0": {"a"}, "user.tags.1": {"b"}, "active": {"true"}}
+	obj, err := jogson.NewObjectFromValues(values, jogson.FormOptions{Notation: jogson.FormDotNotation, InferTypes: true})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"active":true,"user":{"name":"Jason","tags":["a","b"]}}`, obj.String())
+
+	// top-level keys that are indices stay keys of the object
+	obj, err = jogson.NewObjectFromValues(url.Values{"0": {"x"}, "1[0]": {"y"}}, jogson.FormOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"0":"x","1":["y"]}`, obj.String())
+}
+
+func TestObjectToValues(t *testing.T) {
+	obj, err := jogson.NewObjectFromString(`{"name": "Jason", "age": 43.5, "user": {"address": {"city": "Berlin"}, "tags": ["a", "b"], "x": null, "empty": {}}}`)
+	assert.NoError(t, err)
+
+	values := obj.ToValues(jogson.FormOptions{})
+	assert.Equal(t, url.Values{
+		"name":                {"Jason"},
+		"age":                 {"43.5"},
+		"user[address][city]": {"Berlin"},
+		"user[tags][0]":       {"a"},
+		"user[tags][1]":       {"b"},
+		"user[x]":             {""},
+	}, values)
+	assert.Equal(t, "age=43.5&name=Jason&user.address.city=Berlin&user.tags.0=a&user.tags.1=b&user.x=",
+		obj.FormString(jogson.FormOptions{Notation: jogson.FormDotNotation}))
+
+	roundTrip, err := jogson.NewObjectFromValues(values, jogson.FormOptions{InferTypes: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 43.5, roundTrip.GetFloat("age"))
+	assert.Equal(t, []string{"a", "b"}, roundTrip.GetObject("user").GetArray("tags").AsStringArray())
+}
@@ -0,0 +1,150 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewObjectFromINIFile(t *testing.T) {
+	object, err := jogson.NewObjectFromINIFile("files/test_legacy.ini", jogson.INIOptions{InferTypes: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "billing", object.GetString("app_name"))
+	assert.False(t, object.GetBool("debug"))
+
+	database := object.GetObject("database")
+	assert.Equal(t, 5432, database.GetInt("port"))
+	assert.Equal(t, `  s3cr;t "quoted"  `, database.GetString("password"))
+	assert.Equal(t, 10, database.GetObject("pool").GetInt("size"))
+	assert.Equal(t, 2.5, database.GetObject("pool").GetFloat("timeout"))
+	assert.Equal(t, "replica.internal", database.GetObject("replica").GetString("host"))
+
+	paths := object.GetObject("paths")
+	assert.Equal(t, `C:\services\billing`, paths.GetString("root"))
+	assert.Equal(t, "*.tmp, *.bak", paths.GetString("exclude"))
+	assert.Equal(t, []string{"a.internal", "b.internal"}, object.GetObject("servers").GetArray("hosts").AsStringArray())
+}
+
+func TestNewObjectFromINIDuplicateKeys(t *testing.T) {
+	data := []byte("[a]\nx = 1\n[b]\ny = 1\n[a]\nx = 2\nx = 3\n")
+	object, err := jogson.NewObjectFromINI(data, jogson.INIOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "3", object.GetObject("a").GetString("x"))
+	assert.Equal(t, "1", object.GetObject("b").GetString("y"))
+
+	object, err = jogson.NewObjectFromINI(data, jogson.INIOptions{DuplicateKeys: jogson.DuplicateKeyKeepFirst})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", object.GetObject("a").GetString("x"))
+
+	object, err = jogson.NewObjectFromINI(data, jogson.INIOptions{DuplicateKeys: jogson.DuplicateKeyCollect, InferTypes: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, object.GetObject("a").GetArray("x").AsIntArray())
+
+	_, err = jogson.NewObjectFromINI(data, jogson.INIOptions{DuplicateKeys: jogson.DuplicateKeyError})
+	assert.ErrorIs(t, err, jogson.ParseErr)
+	assert.ErrorIs(t, err, jogson.DuplicateKeyErr)
+	var parseErr *jogson.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 6, parseErr.Line)
+}
+
+func TestNewObjectFromINIInvalid(t *testing.T) {
+	tests := map[string]string{
+		"missing bracket":  "[section\nx = 1",
+		"missing equals":   "[section]\nx",
+		"empty key":        "= 1",
+		"unclosed quote":   `x = "abc`,
+		"invalid escape":   `x = "\q"`,
+		"value and object": "a = 1\n[a]\nb = 2",
+		"object and value": "[a]\nb = 2\n[]\n",
+	}
+	for name, data := range tests {
+		_, err := jogson.NewObjectFromINI([]byte(data), jogson.INIOptions{})
+		assert.ErrorIs(t, err, jogson.ParseErr, name)
+	}
+}
+
+func TestNewObjectFromINIIndexKeys(t *testing.T) {
+	// top-level keys that are indices stay keys of the object, nested ones are converted into arrays
+	obj, err := jogson.NewObjectFromINI([]byte("0 =\n1 = a\n[list]\n0 = x\n1 = y\n"), jogson.INIOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"0":"","1":"a","list":["x","y"]}`, obj.String())
+
+	obj, err = jogson.NewObjectFromProperties([]byte("0=\n1=a\n"), jogson.INIOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"0":"","1":"a"}`, obj.String())
+}
+
+func TestObjectINIString(t *testing.T) {
+	object, err := jogson.NewObjectFromString(`{"name": "billing", "version": "1.4", "tags": ["a", "b"], "database": {"port": 5432, "password": " x\"y ", "pool": {"size": 10}, "host": null, "empty": {}}, "cache": {}}`)
+	assert.NoError(t, err)
+	ini, err := object.INIString()
+	assert.NoError(t, err)
+	expected := `name = billing
+tags.0 = a
+tags.1 = b
+version = "1.4"
+
+[cache]
+
+[database]
+host =
+password = " x\"y "
+pool.size = 10
+port = 5432
+`
+	assert.Equal(t, expected, ini)
+
+	roundTrip, err := jogson.NewObjectFromINI([]byte(ini), jogson.INIOptions{InferTypes: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.4", roundTrip.GetString("version"))
+	assert.Equal(t, " x\"y ", roundTrip.GetObject("database").GetString("password"))
+	assert.Equal(t, 0, roundTrip.GetObject("cache").Length())
+
+	object, err = jogson.NewObjectFromString(`{"section": {"a.b": 1}}`)
+	assert.NoError(t, err)
+	_, err = object.INIString()
+	assert.ErrorIs(t, err, jogson.InvalidPathErr)
+}
+
+func TestNewObjectFromPropertiesFile(t *testing.T) {
+	object, err := jogson.NewObjectFromPropertiesFile("files/test_app.properties", jogson.INIOptions{InferTypes: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "billing", object.GetObject("app").GetString("name"))
+	assert.Equal(t, "1.4.0", object.GetObject("app").GetString("version"))
+	assert.Equal(t, 8080, object.GetObject("server").GetInt("port"))
+	assert.Equal(t, []string{"a.internal", "b.internal"}, object.GetObject("server").GetArray("hosts").AsStringArray())
+	assert.Equal(t, "Welcome to billing!", object.GetObject("message").GetString("welcome"))
+	assert.Equal(t, "café 😀", object.GetObject("message").GetString("unicode"))
+	assert.Equal(t, "value\twith tab", object.GetString("key with spaces"))
+	assert.Equal(t, "", object.GetString("empty"))
+
+	_, err = jogson.NewObjectFromProperties([]byte("a=1\na.b=2"), jogson.INIOptions{})
+	assert.ErrorIs(t, err, jogson.ParseErr)
+	_, err = jogson.NewObjectFromProperties([]byte(`a=\u12`), jogson.INIOptions{})
+	assert.ErrorIs(t, err, jogson.ParseErr)
+	_, err = jogson.NewObjectFromProperties([]byte("a=1\na=2"), jogson.INIOptions{DuplicateKeys: jogson.DuplicateKeyError})
+	assert.ErrorIs(t, err, jogson.DuplicateKeyErr)
+}
+
+func TestObjectPropertiesString(t *testing.T) {
+	object, err := jogson.NewObjectFromString(`{"app": {"name": "billing", "tags": ["a", "b"]}, "key with=sep": " leading\nnew line", "path": "C:\\dir", "n": null}`)
+	assert.NoError(t, err)
+	properties, err := object.PropertiesString()
+	assert.NoError(t, err)
+	expected := `app.name=billing
+app.tags.0=a
+app.tags.1=b
+key\ with\=sep=\ leading\nnew line
+n=
+path=C:\\dir
+`
+	assert.Equal(t, expected, properties)
+
+	roundTrip, err := jogson.NewObjectFromProperties([]byte(properties), jogson.INIOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, " leading\nnew line", roundTrip.GetString("key with=sep"))
+	assert.Equal(t, `C:\dir`, roundTrip.GetString("path"))
+	assert.Equal(t, []string{"a", "b"}, roundTrip.GetObject("app").GetArray("tags").AsStringArray())
+}
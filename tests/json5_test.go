@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJSONC(t *testing.T) {
+	opts := jogson.ParseOptions{AllowComments: true, AllowTrailingCommas: true}
+	object, err := jogson.NewObjectFromFileWithOptions("files/test_config.jsonc", opts)
+	assert.NoError(t, err)
+	compilerOptions := object.GetObject("compilerOptions")
+	assert.Equal(t, "es2020", compilerOptions.GetString("target"))
+	assert.True(t, compilerOptions.GetBool("strict"))
+	assert.Equal(t, []string{"src/*", "lib/*"}, compilerOptions.GetArray("paths").AsStringArray())
+
+	_, err = jogson.NewObjectFromFileWithOptions("files/test_config.jsonc", jogson.ParseOptions{AllowComments: true})
+	assert.ErrorIs(t, err, jogson.ParseErr)
+	_, err = jogson.NewObjectFromFileWithOptions("files/test_config.jsonc", jogson.ParseOptions{AllowTrailingCommas: true})
+	assert.ErrorIs(t, err, jogson.ParseErr)
+
+	_, err = jogson.NewObjectFromStringWithOptions(`{"a": 1} /* unterminated`, opts)
+	assert.ErrorIs(t, err, jogson.ParseErr)
+	_, err = jogson.NewArrayFromStringWithOptions(`[1,,]`, opts)
+	assert.ErrorIs(t, err, jogson.ParseErr)
+}
+
+func TestParseJSON5(t *testing.T) {
+	object, err := jogson.NewObjectFromStringWithOptions(jsonJSON5Test, jogson.ParseOptions{JSON5: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "and you can quote me on that", object.GetString("unquoted"))
+	assert.Equal(t, `I can use "double quotes" here`, object.GetString("singleQuotes"))
+	assert.Equal(t, `Look, Mom! No \n's!`, object.GetString("lineBreaks"))
+	assert.Equal(t, 912559, object.GetInt("hexadecimal"))
+	assert.Equal(t, 0.8675309, object.GetFloat("leadingDecimalPoint"))
+	assert.Equal(t, 8675309.0, object.GetFloat("andTrailing"))
+	assert.Equal(t, 1, object.GetInt("positiveSign"))
+	assert.Equal(t, []string{"arrays"}, object.GetArray("andIn").AsStringArray())
+	assert.Equal(t, "with JSON", object.GetString("backwardsCompatible"))
+	assert.Equal(t, "AB'", object.GetString("escapes"))
+
+	mapper, err := jogson.NewMapperFromStringWithOptions(`[Infinity, -Infinity, NaN, -0x10]`, jogson.ParseOptions{JSON5: true})
+	assert.NoError(t, err)
+	array, err := mapper.AsArray()
+	assert.NoError(t, err)
+	assert.True(t, math.IsInf(array.GetFloat(0), 1))
+	assert.True(t, math.IsInf(array.GetFloat(1), -1))
+	assert.True(t, math.IsNaN(array.GetFloat(2)))
+	assert.Equal(t, -16, array.GetInt(3))
+
+	for _, invalid := range []string{`{a: 1}`, `['a']`, `[.5]`, `[0x10]`, `[Infinity]`, `[+1]`} {
+		_, err = jogson.NewMapperFromStringWithOptions(invalid, jogson.ParseOptions{})
+		assert.ErrorIs(t, err, jogson.ParseErr, invalid)
+	}
+	for _, invalid := range []string{`{1a: 1}`, `[.]`, `[0x]`, `[+x]`, `['\1']`, "['a\nb']"} {
+		_, err = jogson.NewMapperFromStringWithOptions(invalid, jogson.ParseOptions{JSON5: true})
+		assert.ErrorIs(t, err, jogson.ParseErr, invalid)
+	}
+}
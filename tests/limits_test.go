@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLimits(t *testing.T) {
+	tests := []struct {
+		json   string
+		opts   jogson.ParseOptions
+		err    error
+		offset int
+	}{
+		{jsonObjectTest, jogson.ParseOptions{MaxInputSize: 10}, jogson.InputTooLargeErr, 10},
+		{`{"a": [[1]]}`, jogson.ParseOptions{MaxDepth: 2}, jogson.MaxDepthExceededErr, 7},
+		{`{"name": "Jason"}`, jogson.ParseOptions{MaxStringLength: 3}, jogson.StringTooLongErr, 1},
+		{`{"a": "Jason"}`, jogson.ParseOptions{MaxStringLength: 4}, jogson.StringTooLongErr, 6},
+		{`{"a": 1, "b": 2, "c": 3}`, jogson.ParseOptions{MaxElements: 2}, jogson.TooManyElementsErr, 17},
+		{`[1, 2, 3]`, jogson.ParseOptions{MaxElements: 2}, jogson.TooManyElementsErr, 7},
+		{`{"a": 1, "a": 2}`, jogson.ParseOptions{DuplicateKeys: jogson.DuplicateKeyError}, jogson.DuplicateKeyErr, 9},
+		{"[\"a\xffb\"]", jogson.ParseOptions{RejectInvalidUTF8: true}, jogson.InvalidUTF8Err, 3},
+		{"[\"\\n\xc3\"]", jogson.ParseOptions{RejectInvalidUTF8: true}, jogson.InvalidUTF8Err, 4},
+	}
+	for _, test := range tests {
+		_, err := jogson.NewMapperFromStringWithOptions(test.json, test.opts)
+		assert.ErrorIs(t, err, test.err, test.json)
+		assert.ErrorIs(t, err, jogson.ParseErr, test.json)
+		var parseErr *jogson.ParseError
+		assert.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, test.offset, parseErr.Offset, test.json)
+
+		_, err = jogson.NewMapperFromStringWithOptions(test.json, jogson.ParseOptions{})
+		assert.NoError(t, err, test.json)
+	}
+
+	opts := jogson.ParseOptions{MaxDepth: 2, MaxStringLength: 5, MaxElements: 3, DuplicateKeys: jogson.DuplicateKeyError, RejectInvalidUTF8: true}
+	array, err := jogson.NewArrayFromStringWithOptions(`[[1, 2, 3], "Jason", "é"]`, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, array.Length())
+
+	_, err = jogson.NewArrayFromStringWithOptions(strings.Repeat("[", 10000)+strings.Repeat("]", 10000), jogson.ParseOptions{MaxDepth: 100})
+	assert.ErrorIs(t, err, jogson.MaxDepthExceededErr)
+
+	// without MaxDepth, the default limit of 10000 applies
+	_, err = jogson.NewArrayFromStringWithOptions(strings.Repeat("[", 10000)+strings.Repeat("]", 10000), jogson.ParseOptions{})
+	assert.NoError(t, err)
+	_, err = jogson.NewArrayFromStringWithOptions(strings.Repeat("[", 1000000), jogson.ParseOptions{AllowComments: true})
+	assert.ErrorIs(t, err, jogson.MaxDepthExceededErr)
+}
+
+func TestParseLimitsFromFile(t *testing.T) {
+	_, err := jogson.NewObjectFromFileWithOptions("files/test_object.json", jogson.ParseOptions{MaxInputSize: 10})
+	assert.ErrorIs(t, err, jogson.InputTooLargeErr)
+	assert.True(t, strings.HasPrefix(err.Error(), "parse error: files/test_object.json: input too large"))
+}
+
+func TestDuplicateKeyPolicies(t *testing.T) {
+	data := "{\"a\": 1, \"b\": [0],\n \"a\": 2, \"b\": [1], \"a\": 3}"
+	object, err := jogson.NewObjectFromStringWithOptions(data, jogson.ParseOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":3,"b":[1]}`, object.String())
+
+	object, err = jogson.NewObjectFromStringWithOptions(data, jogson.ParseOptions{DuplicateKeys: jogson.DuplicateKeyKeepFirst, RecordPositions: true})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1,"b":[0]}`, object.String())
+	span, ok := object.Position("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, span.Start.Line)
+
+	object, err = jogson.NewObjectFromStringWithOptions(data, jogson.ParseOptions{DuplicateKeys: jogson.DuplicateKeyCollect})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":[1,2,3],"b":[[0],[1]]}`, object.String())
+
+	_, err = jogson.NewObjectFromStringWithOptions(data, jogson.ParseOptions{DuplicateKeys: jogson.DuplicateKeyError})
+	assert.ErrorIs(t, err, jogson.DuplicateKeyErr)
+	var parseErr *jogson.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 2, parseErr.Line)
+	assert.Equal(t, 2, parseErr.Column)
+	assert.Equal(t, "parse error: 2:2: duplicate key: 'a', first defined at 1:2", err.Error())
+}
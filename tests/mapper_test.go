@@ -1,6 +1,8 @@
 package tests
 
 import (
+	"math"
+	"math/big"
 	"testing"
 
 	"github.com/rmordechay/jogson"
@@ -10,7 +12,9 @@ import (
 func TestParseTimeInvalid(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonInvalidTimeTest)
 	assert.NoError(t, err)
-	for _, v := range mapper.AsObject.Elements() {
+	object, err := mapper.AsObject()
+	assert.NoError(t, err)
+	for _, v := range object.Elements() {
 		_, err = v.AsTime()
 		assert.Error(t, err)
 	}
@@ -29,7 +33,7 @@ func TestMapperString(t *testing.T) {
 
 	mapper, err = jogson.NewMapperFromString(jsonOnlyStringTest)
 	assert.NoError(t, err)
-	assert.Equal(t, "test", mapper.String())
+	assert.Equal(t, `"test"`, mapper.String())
 
 	mapper, err = jogson.NewMapperFromString(jsonOnlyIntTest)
 	assert.NoError(t, err)
@@ -45,7 +49,8 @@ func TestMapperString(t *testing.T) {
 
 	mapper, err = jogson.NewMapperFromString(jsonOnlyNullTest)
 	assert.NoError(t, err)
-	assert.True(t, mapper.IsNull)
+	assert.True(t, mapper.IsNull())
+	assert.Equal(t, "null", mapper.String())
 }
 
 func TestJsonInvalid(t *testing.T) {
@@ -63,6 +68,88 @@ func TestJsonInvalid(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestMapperScalars(t *testing.T) {
+	mapper, err := jogson.NewMapperFromString(`"a \"quoted\" \u00e9 string"`)
+	assert.NoError(t, err)
+	s, err := mapper.AsString()
+	assert.NoError(t, err)
+	assert.Equal(t, `a "quoted" é string`, s)
+
+	mapper, err = jogson.NewMapperFromString(" 1e3 ")
+	assert.NoError(t, err)
+	i, err := mapper.AsInt()
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, i)
+
+	for _, invalid := range []string{"hello", "True", "1 2", "'test'", `"unterminated`, ""} {
+		mapper, err = jogson.NewMapperFromString(invalid)
+		assert.Error(t, err, invalid)
+		assert.Zero(t, mapper)
+	}
+}
+
+func TestNewMapperFromValue(t *testing.T) {
+	mapper, err := jogson.NewMapperFromValue(int64(42))
+	assert.NoError(t, err)
+	i, err := mapper.AsInt()
+	assert.NoError(t, err)
+	assert.Equal(t, 42, i)
+
+	mapper, err = jogson.NewMapperFromValue(float32(1.5))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", mapper.String())
+
+	mapper, err = jogson.NewMapperFromValue(nil)
+	assert.NoError(t, err)
+	assert.True(t, mapper.IsNull())
+
+	mapper, err = jogson.NewMapperFromValue(map[string]any{"name": "Jason", "ids": []any{uint8(1), nil}})
+	assert.NoError(t, err)
+	assert.True(t, mapper.IsObject())
+	assert.Equal(t, `{"ids":[1,null],"name":"Jason"}`, mapper.String())
+
+	mapper, err = jogson.NewMapperFromValue(struct {
+		Name string `json:"name"`
+	}{"Chris"})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"Chris"}`, mapper.String())
+
+	array, err := jogson.NewArrayFromString(jsonIntArrayTest)
+	assert.NoError(t, err)
+	mapper, err = jogson.NewMapperFromValue(array)
+	assert.NoError(t, err)
+	assert.True(t, mapper.IsArray())
+	assert.Equal(t, array.String(), mapper.String())
+
+	_, err = jogson.NewMapperFromValue(make(chan int))
+	assert.Error(t, err)
+}
+
+func TestNewMapperFromValueBigInt(t *testing.T) {
+	mapper, err := jogson.NewMapperFromValue(uint64(math.MaxUint64))
+	assert.NoError(t, err)
+	assert.True(t, mapper.IsNumber())
+	assert.Equal(t, "18446744073709551615", mapper.String())
+	n, err := mapper.AsBigInt()
+	assert.NoError(t, err)
+	assert.Equal(t, "18446744073709551615", n.String())
+
+	// big integers that fit into int are ints
+	mapper, err = jogson.NewMapperFromValue(big.NewInt(42))
+	assert.NoError(t, err)
+	i, err := mapper.AsInt()
+	assert.NoError(t, err)
+	assert.Equal(t, 42, i)
+
+	mapper, err = jogson.NewMapperFromValue(map[string]any{"big": uint64(math.MaxUint64)})
+	assert.NoError(t, err)
+	assert.Equal(t, "big: 18446744073709551615\n", mapper.YAMLString())
+	object, err := mapper.AsObject()
+	assert.NoError(t, err)
+	_, err = object.TOMLString()
+	assert.ErrorIs(t, err, jogson.TOMLConversionErr)
+}
+
 //func TestProcessObjects(t *testing.T) {
 //	n := 1000
 //	array, _ := generateJSONArray(n)
@@ -89,6 +176,93 @@ func TestJsonInvalid(t *testing.T) {
 //	assert.Equal(t, n, c)
 //}
 
+func TestMapperKind(t *testing.T) {
+	tests := []struct {
+		json     string
+		kind     jogson.Kind
+		typeName string
+	}{
+		{jsonOnlyNullTest, jogson.Null, "null"},
+		{jsonOnlyBoolTest, jogson.Bool, "bool"},
+		{jsonOnlyIntTest, jogson.Number, "number"},
+		{jsonOnlyFloatTest, jogson.Number, "number"},
+		{jsonOnlyStringTest, jogson.String, "string"},
+		{jsonObjectTest, jogson.Object, "object"},
+		{jsonObjectArrayTest, jogson.Array, "array"},
+	}
+	for _, test := range tests {
+		mapper, err := jogson.NewMapperFromString(test.json)
+		assert.NoError(t, err)
+		assert.Equal(t, test.kind, mapper.Kind())
+		assert.Equal(t, test.typeName, mapper.TypeName())
+		assert.Equal(t, test.kind == jogson.Null, mapper.IsNull())
+		assert.Equal(t, test.kind == jogson.Bool, mapper.IsBool())
+		assert.Equal(t, test.kind == jogson.Number, mapper.IsNumber())
+		assert.Equal(t, test.kind == jogson.String, mapper.IsString())
+		assert.Equal(t, test.kind == jogson.Object, mapper.IsObject())
+		assert.Equal(t, test.kind == jogson.Array, mapper.IsArray())
+	}
+}
+
+func TestMapperAccessors(t *testing.T) {
+	mapper, err := jogson.NewMapperFromString(jsonOnlyIntTest)
+	assert.NoError(t, err)
+	i, err := mapper.AsInt()
+	assert.NoError(t, err)
+	assert.Equal(t, 56, i)
+	f, err := mapper.AsFloat()
+	assert.NoError(t, err)
+	assert.Equal(t, 56.0, f)
+	assert.True(t, mapper.IsInt())
+	assert.False(t, mapper.IsFloat())
+	_, err = mapper.AsString()
+	assert.ErrorIs(t, err, jogson.TypeConversionErr)
+	assert.EqualError(t, err, "type conversion error: number could not be converted to string")
+	_, err = mapper.AsBool()
+	assert.ErrorIs(t, err, jogson.TypeConversionErr)
+	obj, err := mapper.AsObject()
+	assert.ErrorIs(t, err, jogson.TypeConversionErr)
+	assert.True(t, obj.IsNull())
+	arr, err := mapper.AsArray()
+	assert.ErrorIs(t, err, jogson.TypeConversionErr)
+	assert.True(t, arr.IsNull())
+	_, err = mapper.AsUUID()
+	assert.ErrorIs(t, err, jogson.TypeConversionErr)
+
+	mapper, err = jogson.NewMapperFromString(jsonOnlyFloatTest)
+	assert.NoError(t, err)
+	_, err = mapper.AsInt()
+	assert.ErrorIs(t, err, jogson.TypeConversionErr)
+	assert.True(t, mapper.IsFloat())
+	assert.False(t, mapper.IsInt())
+
+	mapper, err = jogson.NewMapperFromString(jsonOnlyStringTest)
+	assert.NoError(t, err)
+	s, err := mapper.AsString()
+	assert.NoError(t, err)
+	assert.Equal(t, "test", s)
+	_, err = mapper.AsInt()
+	assert.ErrorIs(t, err, jogson.TypeConversionErr)
+
+	mapper, err = jogson.NewMapperFromString(jsonOnlyBoolTest)
+	assert.NoError(t, err)
+	b, err := mapper.AsBool()
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	mapper, err = jogson.NewMapperFromString(jsonObjectTest)
+	assert.NoError(t, err)
+	obj, err = mapper.AsObject()
+	assert.NoError(t, err)
+	assert.Equal(t, "Jason", obj.GetString("name"))
+
+	mapper, err = jogson.NewMapperFromString(jsonObjectArrayTest)
+	assert.NoError(t, err)
+	arr, err = mapper.AsArray()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, arr.Length())
+}
+
 func TestMapperPrettyString(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonObjectTest)
 	assert.NoError(t, err)
@@ -102,7 +276,7 @@ func TestMapperPrettyString(t *testing.T) {
 
 	mapper, err = jogson.NewMapperFromString(jsonOnlyStringTest)
 	assert.NoError(t, err)
-	assert.Equal(t, "test", mapper.PrettyString())
+	assert.Equal(t, `"test"`, mapper.PrettyString())
 
 	mapper, err = jogson.NewMapperFromString(jsonOnlyIntTest)
 	assert.NoError(t, err)
@@ -118,7 +292,8 @@ func TestMapperPrettyString(t *testing.T) {
 
 	mapper, err = jogson.NewMapperFromString(jsonOnlyNullTest)
 	assert.NoError(t, err)
-	assert.True(t, mapper.IsNull)
+	assert.True(t, mapper.IsNull())
+	assert.Equal(t, "null", mapper.PrettyString())
 }
 
 func TestExample(t *testing.T) {
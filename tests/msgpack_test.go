@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMsgPackBytes(t *testing.T) {
+	object, err := jogson.NewObjectFromString(`{"b": [true, null, "x"], "a": 1}`)
+	assert.NoError(t, err)
+	expected := []byte{0x82, 0xa1, 'a', 0x01, 0xa1, 'b', 0x93, 0xc3, 0xc0, 0xa1, 'x'}
+	assert.Equal(t, expected, object.MsgPackBytes())
+
+	tests := []struct {
+		json     string
+		expected []byte
+	}{
+		{`-33`, []byte{0xd0, 0xdf}},
+		{`-1`, []byte{0xff}},
+		{`200`, []byte{0xcc, 0xc8}},
+		{`70000`, []byte{0xce, 0x00, 0x01, 0x11, 0x70}},
+		{`1.5`, []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{`"` + string(bytes.Repeat([]byte("a"), 40)) + `"`, append([]byte{0xd9, 40}, bytes.Repeat([]byte("a"), 40)...)},
+	}
+	for _, test := range tests {
+		mapper, err := jogson.NewMapperFromString(test.json)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, mapper.MsgPackBytes(), test.json)
+	}
+
+	array, err := jogson.NewArrayFromString(`[1, {"name": "Jason", "children": [{"age": 15.5}]}, [], "text"]`)
+	assert.NoError(t, err)
+	roundTrip, err := jogson.NewArrayFromMsgPack(array.MsgPackBytes())
+	assert.NoError(t, err)
+	assert.Equal(t, array.String(), roundTrip.String())
+}
+
+func TestNewMapperFromMsgPack(t *testing.T) {
+	data := []byte{
+		0x85,
+		0xa3, 'b', 'i', 'n', 0xc4, 0x03, 0x01, 0x02, 0x03,
+		0xa4, 't', 'i', 'm', 'e', 0xd6, 0xff, 0x00, 0x00, 0x00, 0x3c,
+		0xa3, 'b', 'i', 'g', 0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0x07, 0xa5, 's', 'e', 'v', 'e', 'n',
+		0xa3, 'f', '3', '2', 0xca, 0x3f, 0xc0, 0x00, 0x00,
+	}
+	object, err := jogson.NewObjectFromMsgPack(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "AQID", object.GetString("bin"))
+	assert.Equal(t, "1970-01-01T00:01:00Z", object.GetString("time"))
+	assert.Equal(t, 1, object.GetTime("time").Minute())
+	big := object.Get("big")
+	assert.True(t, big.IsNumber())
+	assert.True(t, big.IsInt())
+	assert.Equal(t, "18446744073709551615", big.String())
+	assert.Equal(t, "18446744073709551615", object.GetBigInt("big").String())
+	_, err = big.AsInt()
+	assert.ErrorIs(t, err, jogson.TypeConversionErr)
+	assert.Equal(t, []byte{0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, big.MsgPackBytes())
+	assert.Equal(t, "seven", object.GetString("7"))
+	assert.Equal(t, 1.5, object.GetFloat("f32"))
+
+	tests := []struct {
+		data    []byte
+		message string
+	}{
+		{[]byte{}, "parse error: msgpack: offset 0: unexpected end of input"},
+		{[]byte{0x92, 0x01}, "parse error: msgpack: offset 2: unexpected end of input"},
+		{[]byte{0xc1}, "parse error: msgpack: offset 0: invalid format 0xc1"},
+		{[]byte{0x01, 0x02}, "parse error: msgpack: offset 1: unexpected data after the top-level value"},
+		{[]byte{0x81, 0x90, 0x01}, "parse error: msgpack: offset 1: map key of type array cannot be represented in JSON"},
+		{[]byte{0xd4, 0x05, 0x00}, "parse error: msgpack: offset 0: unsupported extension type 5"},
+		{[]byte{0xcb, 0x7f, 0xf8, 0, 0, 0, 0, 0, 1}, "parse error: msgpack: offset 0: NaN cannot be represented in JSON"},
+		{[]byte{0xdd, 0xff, 0xff, 0xff, 0xff}, "parse error: msgpack: offset 5: unexpected end of input"},
+	}
+	for _, test := range tests {
+		_, err := jogson.NewMapperFromMsgPack(test.data)
+		assert.ErrorIs(t, err, jogson.ParseErr)
+		assert.EqualError(t, err, test.message)
+	}
+
+	_, err = jogson.NewObjectFromMsgPack([]byte{0x90})
+	assert.ErrorIs(t, err, jogson.TypeConversionErr)
+}
@@ -12,7 +12,8 @@ import (
 func TestObjectGetKeys(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonObjectTest)
 	assert.NoError(t, err)
-	keys := mapper.AsObject.Keys()
+	object, _ := mapper.AsObject()
+	keys := object.Keys()
 	assert.Equal(t, 5, len(keys))
 	assert.Contains(t, keys, "name")
 	assert.Contains(t, keys, "age")
@@ -22,10 +23,12 @@ func TestObjectGetKeys(t *testing.T) {
 func TestObjectGetValues(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonObjectTest)
 	assert.NoError(t, err)
-	values := mapper.AsObject.Values()
+	object, _ := mapper.AsObject()
+	values := object.Values()
 	assert.Equal(t, 5, len(values))
 	for _, v := range values {
-		assert.True(t, v.AsString == "Jason" || v.IsNull || v.AsInt == 15 || v.AsBool || v.AsFloat == 1.81)
+		s := v.String()
+		assert.True(t, s == `"Jason"` || v.IsNull() || s == "15" || s == "true" || s == "1.81")
 	}
 }
 
@@ -113,40 +116,40 @@ func TestObjectAsArrayMap(t *testing.T) {
 
 func TestObjectGetMapper(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectTest)
-	array := mapper.AsObject
+	array, _ := mapper.AsObject()
 
 	elementMapper := array.Get("name")
 	assert.NoError(t, array.LastError)
-	assert.Equal(t, "Jason", elementMapper.AsString)
+	assert.Equal(t, `"Jason"`, elementMapper.String())
 
 	array.LastError = nil
 	elementMapper = array.Get("age")
 	assert.NoError(t, array.LastError)
-	assert.Equal(t, 15, elementMapper.AsInt)
-	assert.True(t, elementMapper.IsInt)
+	assert.Equal(t, "15", elementMapper.String())
+	assert.True(t, elementMapper.IsInt())
 
 	array.LastError = nil
 	elementMapper = array.Get("address")
 	assert.NoError(t, array.LastError)
-	assert.True(t, elementMapper.IsNull)
+	assert.True(t, elementMapper.IsNull())
 
 	array.LastError = nil
 	elementMapper = array.Get("is_funny")
 	assert.NoError(t, array.LastError)
-	assert.Equal(t, true, elementMapper.AsBool)
-	assert.True(t, elementMapper.IsBool)
+	assert.Equal(t, "true", elementMapper.String())
+	assert.True(t, elementMapper.IsBool())
 
 	array.LastError = nil
 	elementMapper = array.Get("height")
 	assert.NoError(t, array.LastError)
-	assert.Equal(t, 1.81, elementMapper.AsFloat)
-	assert.True(t, elementMapper.IsFloat)
+	assert.Equal(t, "1.81", elementMapper.String())
+	assert.True(t, elementMapper.IsFloat())
 
 }
 
 func TestObjectGetString(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectTest)
-	object := mapper.AsObject
+	object, _ := mapper.AsObject()
 
 	s := object.GetString("name")
 	assert.NoError(t, object.LastError)
@@ -174,7 +177,7 @@ func TestObjectGetStringN(t *testing.T) {
 
 func TestObjectGetStringFails(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectTest)
-	object := mapper.AsObject
+	object, _ := mapper.AsObject()
 
 	s := object.GetString("not found")
 	assert.ErrorIs(t, object.LastError, jogson.KeyNotFoundErr)
@@ -187,7 +190,7 @@ func TestObjectGetStringFails(t *testing.T) {
 
 func TestObjectGetInt(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectTest)
-	object := mapper.AsObject
+	object, _ := mapper.AsObject()
 
 	i := object.GetInt("age")
 	assert.NoError(t, object.LastError)
@@ -207,7 +210,7 @@ func TestObjectGetIntN(t *testing.T) {
 
 func TestObjectGetIntFails(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectTest)
-	object := mapper.AsObject
+	object, _ := mapper.AsObject()
 
 	i := object.GetInt("not found")
 	assert.ErrorIs(t, object.LastError, jogson.KeyNotFoundErr)
@@ -224,7 +227,7 @@ func TestObjectGetIntFails(t *testing.T) {
 
 func TestObjectGetFloat(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectTest)
-	object := mapper.AsObject
+	object, _ := mapper.AsObject()
 
 	f := object.GetFloat("age")
 	assert.NoError(t, object.LastError)
@@ -244,7 +247,7 @@ func TestObjectGetFloatN(t *testing.T) {
 
 func TestObjectGetFloatFails(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectTest)
-	object := mapper.AsObject
+	object, _ := mapper.AsObject()
 
 	f := object.GetFloat("not found")
 	assert.ErrorIs(t, object.LastError, jogson.KeyNotFoundErr)
@@ -261,7 +264,7 @@ func TestObjectGetFloatFails(t *testing.T) {
 
 func TestObjectGetBool(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectTest)
-	object := mapper.AsObject
+	object, _ := mapper.AsObject()
 
 	b := object.GetBool("is_funny")
 	assert.NoError(t, object.LastError)
@@ -277,7 +280,7 @@ func TestObjectGetBoolN(t *testing.T) {
 
 func TestObjectGetBoolFails(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectTest)
-	object := mapper.AsObject
+	object, _ := mapper.AsObject()
 
 	b := object.GetBool("not found")
 	assert.ErrorIs(t, object.LastError, jogson.KeyNotFoundErr)
@@ -294,7 +297,7 @@ func TestObjectGetBoolFails(t *testing.T) {
 
 func TestObjectGetArray(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectWithArrayTest)
-	object := mapper.AsObject
+	object, _ := mapper.AsObject()
 
 	array := object.GetArray("names")
 	assert.ElementsMatch(t, []string{"Jason", "Chris", "Rachel"}, array.AsStringArray())
@@ -302,7 +305,7 @@ func TestObjectGetArray(t *testing.T) {
 
 func TestObjectGetArrayFails(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectWithArrayTest)
-	object := mapper.AsObject
+	object, _ := mapper.AsObject()
 
 	arr := object.GetArray("not found")
 	assert.ErrorIs(t, object.LastError, jogson.KeyNotFoundErr)
@@ -319,7 +322,7 @@ func TestObjectGetArrayFails(t *testing.T) {
 
 func TestObjectGetObject(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectNestedArrayTest)
-	object := mapper.AsObject
+	object, _ := mapper.AsObject()
 
 	obj := object.GetObject("personTest")
 	assert.Equal(t, "Jason", obj.GetString("name"))
@@ -327,7 +330,7 @@ func TestObjectGetObject(t *testing.T) {
 
 func TestObjectGetObjectFails(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectTest)
-	object := mapper.AsObject
+	object, _ := mapper.AsObject()
 
 	obj := object.GetObject("not found")
 	assert.ErrorIs(t, object.LastError, jogson.KeyNotFoundErr)
@@ -345,7 +348,7 @@ func TestObjectGetObjectFails(t *testing.T) {
 func TestObjectGetTime(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonObjectTimeTest)
 	assert.NoError(t, err)
-	object := mapper.AsObject
+	object, _ := mapper.AsObject()
 	actualTime1 := object.GetTime("time1")
 	assert.NoError(t, object.LastError)
 	actualTime2 := object.GetTime("time2")
@@ -363,7 +366,8 @@ func TestObjectGetTime(t *testing.T) {
 func TestObjectGetUUID(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonUUIDObjectTest)
 	assert.NoError(t, err)
-	uuid, err := mapper.AsObject.Get("uuid").AsUUID()
+	object, _ := mapper.AsObject()
+	uuid, err := object.Get("uuid").AsUUID()
 	assert.NoError(t, err)
 	assert.Equal(t, "870fb3fd-d177-4ac4-a648-a33afd5ab288", uuid.String())
 
@@ -391,7 +395,7 @@ func TestObjectToStruct(t *testing.T) {
 
 func TestObjectIsNull(t *testing.T) {
 	mapper, _ := jogson.NewMapperFromString(jsonObjectTest)
-	object := mapper.AsObject
+	object, _ := mapper.AsObject()
 	nullObj := object.GetObject("address")
 	assert.True(t, nullObj.IsNull())
 }
@@ -407,29 +411,32 @@ func TestObjectIsEmpty(t *testing.T) {
 func TestObjectPrintString(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonObjectTest)
 	assert.NoError(t, err)
-	s := mapper.AsObject.String()
+	object, _ := mapper.AsObject()
+	s := object.String()
 	assert.Equal(t, `{"address":null,"age":15,"height":1.81,"is_funny":true,"name":"Jason"}`, s)
 }
 
 func TestObjectPrettyString(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonObjectTest)
 	assert.NoError(t, err)
+	object, _ := mapper.AsObject()
 	expectedStr := "{\n  \"address\": null,\n  \"age\": 15,\n  \"height\": 1.81,\n  \"is_funny\": true,\n  \"name\": \"Jason\"\n}"
-	assert.Equal(t, expectedStr, mapper.AsObject.PrettyString())
+	assert.Equal(t, expectedStr, object.PrettyString())
 }
 
 func TestElementNotFound(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonObjectTest)
 	assert.NoError(t, err)
-	_ = mapper.AsObject.GetFloat("not found")
-	assert.Error(t, mapper.AsObject.LastError)
-	assert.ErrorIs(t, mapper.AsObject.LastError, jogson.KeyNotFoundErr)
+	object, _ := mapper.AsObject()
+	_ = object.GetFloat("not found")
+	assert.Error(t, object.LastError)
+	assert.ErrorIs(t, object.LastError, jogson.KeyNotFoundErr)
 }
 
 func TestConvertKeysToSnakeCase(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonObjectKeysPascalCaseTest)
 	assert.NoError(t, err)
-	object := mapper.AsObject
+	object, _ := mapper.AsObject()
 	snakeCase := object.TransformKeys(func(s string) string {
 		newString := []rune(s)
 		newString[0] = unicode.ToLower(newString[0])
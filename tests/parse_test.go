@@ -13,10 +13,11 @@ import (
 func TestParseJsonObjectFromString(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonObjectTest)
 	assert.NoError(t, err)
+	object, _ := mapper.AsObject()
 
-	actual := removeWhiteSpaces(mapper.AsObject.String())
+	actual := removeWhiteSpaces(object.String())
 
-	assert.True(t, mapper.IsObject)
+	assert.True(t, mapper.IsObject())
 	assert.Contains(t, actual, `"age":15`)
 	assert.Contains(t, actual, `"name":"Jason"`)
 
@@ -29,13 +30,14 @@ func TestParseJsonObjectFromString(t *testing.T) {
 func TestParseJsonArrayFromString(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonObjectArrayTest)
 	assert.NoError(t, err)
+	mapperArray, _ := mapper.AsArray()
 
-	actual := removeWhiteSpaces(mapper.AsArray.String())
+	actual := removeWhiteSpaces(mapperArray.String())
 	expected := removeWhiteSpaces(jsonObjectArrayTest)
 
-	assert.True(t, mapper.IsArray)
+	assert.True(t, mapper.IsArray())
 	assert.Equal(t, expected, actual)
-	assert.Equal(t, mapper.AsArray.Length(), 2)
+	assert.Equal(t, mapperArray.Length(), 2)
 
 	array, err := jogson.NewArrayFromString(jsonObjectArrayTest)
 	assert.NoError(t, err)
@@ -46,13 +48,14 @@ func TestParseJsonArrayFromString(t *testing.T) {
 func TestParseJsonArrayFromStringWithNulls(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonArrayWithNullTest)
 	assert.NoError(t, err)
+	mapperArray, _ := mapper.AsArray()
 
-	actual := removeWhiteSpaces(mapper.AsArray.String())
+	actual := removeWhiteSpaces(mapperArray.String())
 	expected := removeWhiteSpaces(jsonArrayWithNullTest)
 
-	assert.True(t, mapper.IsArray)
+	assert.True(t, mapper.IsArray())
 	assert.Equal(t, expected, actual)
-	assert.Equal(t, 4, mapper.AsArray.Length())
+	assert.Equal(t, 4, mapperArray.Length())
 
 	array, err := jogson.NewArrayFromString(jsonArrayWithNullTest)
 	assert.NoError(t, err)
@@ -63,10 +66,11 @@ func TestParseJsonArrayFromStringWithNulls(t *testing.T) {
 func TestParseJsonObjectFromBytes(t *testing.T) {
 	mapper, err := jogson.NewMapperFromBytes([]byte(jsonObjectTest))
 	assert.NoError(t, err)
+	object, _ := mapper.AsObject()
 
-	actual := removeWhiteSpaces(mapper.AsObject.String())
+	actual := removeWhiteSpaces(object.String())
 
-	assert.True(t, mapper.IsObject)
+	assert.True(t, mapper.IsObject())
 	assert.Contains(t, actual, `"age":15`)
 	assert.Contains(t, actual, `"name":"Jason"`)
 
@@ -79,13 +83,14 @@ func TestParseJsonObjectFromBytes(t *testing.T) {
 func TestParseJsonArrayFromBytes(t *testing.T) {
 	mapper, err := jogson.NewMapperFromBytes([]byte(jsonObjectArrayTest))
 	assert.NoError(t, err)
+	mapperArray, _ := mapper.AsArray()
 
-	actual := removeWhiteSpaces(mapper.AsArray.String())
+	actual := removeWhiteSpaces(mapperArray.String())
 	expected := removeWhiteSpaces(jsonObjectArrayTest)
 
-	assert.True(t, mapper.IsArray)
+	assert.True(t, mapper.IsArray())
 	assert.Equal(t, expected, actual)
-	assert.Equal(t, mapper.AsArray.Length(), 2)
+	assert.Equal(t, mapperArray.Length(), 2)
 
 	array, err := jogson.NewArrayFromBytes([]byte(jsonObjectArrayTest))
 	assert.NoError(t, err)
@@ -100,9 +105,10 @@ func TestParseJsonArrayFromStruct(t *testing.T) {
 	}{"John", 15}
 	mapper, err := jogson.NewMapperFromStruct(testStruct)
 	assert.NoError(t, err)
-	assert.True(t, mapper.IsObject)
-	assert.Equal(t, "John", mapper.AsObject.GetString("name"))
-	assert.Equal(t, 15, mapper.AsObject.GetInt("Age"))
+	object, _ := mapper.AsObject()
+	assert.True(t, mapper.IsObject())
+	assert.Equal(t, "John", object.GetString("name"))
+	assert.Equal(t, 15, object.GetInt("Age"))
 
 	obj, err := jogson.NewObjectFromStruct(testStruct)
 	assert.NoError(t, err)
@@ -129,15 +135,16 @@ func TestParseJsonArrayFromStruct2(t *testing.T) {
 	person := getTestPerson()
 	mapper, err := jogson.NewMapperFromStruct(person)
 	assert.NoError(t, err)
-	getTime := mapper.AsObject.GetTime("Birthday")
+	object, _ := mapper.AsObject()
+	getTime := object.GetTime("Birthday")
 	expectedBirthday, _ := time.Parse(time.DateOnly, "1981-05-30")
 
-	assert.Equal(t, 45, mapper.AsObject.GetInt("Age"))
-	assert.Equal(t, "1981-05-30T00:00:00Z", mapper.AsObject.GetString("Birthday"))
-	assert.NoError(t, mapper.AsObject.LastError)
+	assert.Equal(t, 45, object.GetInt("Age"))
+	assert.Equal(t, "1981-05-30T00:00:00Z", object.GetString("Birthday"))
+	assert.NoError(t, object.LastError)
 	assert.Equal(t, expectedBirthday, getTime)
-	assert.Equal(t, 1.85, mapper.AsObject.GetFloat("Height"))
-	assert.Equal(t, true, mapper.AsObject.GetBool("IsFunny"))
+	assert.Equal(t, 1.85, object.GetFloat("Height"))
+	assert.Equal(t, true, object.GetBool("IsFunny"))
 
 	obj, err := jogson.NewObjectFromStruct(person)
 	assert.NoError(t, err)
@@ -154,36 +161,76 @@ func TestParseJsonObjectFromFile(t *testing.T) {
 	path := "files/test_object.json"
 	mapper, err := jogson.NewMapperFromFile(path)
 	assert.NoError(t, err)
+	object, _ := mapper.AsObject()
 
-	actual := removeWhiteSpaces(mapper.AsObject.String())
+	actual := removeWhiteSpaces(object.String())
 	fileExpected, err := os.ReadFile(path)
 	expected := removeWhiteSpaces(string(fileExpected))
 
 	assert.NoError(t, err)
-	assert.True(t, mapper.IsObject)
+	assert.True(t, mapper.IsObject())
 	assert.Equal(t, expected, actual)
-	assert.Equal(t, 43, mapper.AsObject.GetInt("age"))
+	assert.Equal(t, 43, object.GetInt("age"))
 
 	obj, err := jogson.NewObjectFromFile(path)
 	assert.NoError(t, err)
-	assert.True(t, mapper.IsObject)
+	assert.True(t, mapper.IsObject())
 	assert.Equal(t, expected, removeWhiteSpaces(obj.String()))
 	assert.Equal(t, 43, obj.GetInt("age"))
 }
 
+func TestParseError(t *testing.T) {
+	_, err := jogson.NewObjectFromString(jsonMissingCommaTest)
+	var parseErr *jogson.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.ErrorIs(t, err, jogson.ParseErr)
+	assert.Equal(t, 3, parseErr.Line)
+	assert.Equal(t, 13, parseErr.Column)
+	assert.Equal(t, 33, parseErr.Offset)
+	assert.Equal(t, ",", parseErr.Token)
+	assert.Equal(t, "  \"age\": 15,,\n            ^", parseErr.Excerpt)
+	assert.Empty(t, parseErr.Path)
+
+	_, err = jogson.NewArrayFromString(`{"name": "Jason"}`)
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, "parse error: 1:1: cannot unmarshal object into array", err.Error())
+
+	_, err = jogson.NewMapperFromString(`[1, 2`)
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 5, parseErr.Offset)
+	assert.Empty(t, parseErr.Token)
+}
+
+func TestParseErrorFromFile(t *testing.T) {
+	path := "files/test_invalid_object.json"
+	_, err := jogson.NewObjectFromFile(path)
+	var parseErr *jogson.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, path, parseErr.Path)
+	assert.Equal(t, 4, parseErr.Line)
+	assert.Equal(t, 3, parseErr.Column)
+	assert.Equal(t, `"address"`, parseErr.Token)
+	assert.True(t, strings.HasPrefix(err.Error(), "parse error: files/test_invalid_object.json:4:3: "))
+
+	_, err = jogson.NewMapperFromFile(path)
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, path, parseErr.Path)
+}
+
 func TestParseJsonArrayFromFile(t *testing.T) {
 	path := "files/test_array.json"
 	mapper, err := jogson.NewMapperFromFile(path)
 	assert.NoError(t, err)
+	mapperArray, _ := mapper.AsArray()
 
-	actual := removeWhiteSpaces(mapper.AsArray.String())
+	actual := removeWhiteSpaces(mapperArray.String())
 	fileExpected, err := os.ReadFile(path)
 	expected := removeWhiteSpaces(string(fileExpected))
 
 	assert.NoError(t, err)
-	assert.True(t, mapper.IsArray)
+	assert.True(t, mapper.IsArray())
 	assert.Equal(t, expected, actual)
-	assert.Equal(t, mapper.AsArray.Length(), 2)
+	assert.Equal(t, mapperArray.Length(), 2)
 
 	array, err := jogson.NewArrayFromFile(path)
 	assert.NoError(t, err)
@@ -194,35 +241,43 @@ func TestParseJsonArrayFromFile(t *testing.T) {
 func TestParseOnlyString(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonOnlyStringTest)
 	assert.NoError(t, err)
-	assert.True(t, mapper.IsString)
-	assert.Equal(t, "test", mapper.AsString)
+	assert.True(t, mapper.IsString())
+	value, err := mapper.AsString()
+	assert.NoError(t, err)
+	assert.Equal(t, "test", value)
 }
 
 func TestParseOnlyInt(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonOnlyIntTest)
 	assert.NoError(t, err)
-	assert.True(t, mapper.IsInt)
-	assert.Equal(t, 56, mapper.AsInt)
+	assert.True(t, mapper.IsInt())
+	value, err := mapper.AsInt()
+	assert.NoError(t, err)
+	assert.Equal(t, 56, value)
 }
 
 func TestParseOnlyFloat(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonOnlyFloatTest)
 	assert.NoError(t, err)
-	assert.True(t, mapper.IsFloat)
-	assert.Equal(t, 1.2, mapper.AsFloat)
+	assert.True(t, mapper.IsFloat())
+	value, err := mapper.AsFloat()
+	assert.NoError(t, err)
+	assert.Equal(t, 1.2, value)
 }
 
 func TestParseOnlyBool(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonOnlyBoolTest)
 	assert.NoError(t, err)
-	assert.True(t, mapper.IsBool)
-	assert.Equal(t, true, mapper.AsBool)
+	assert.True(t, mapper.IsBool())
+	value, err := mapper.AsBool()
+	assert.NoError(t, err)
+	assert.Equal(t, true, value)
 }
 
 func TestParseOnlyNull(t *testing.T) {
 	mapper, err := jogson.NewMapperFromString(jsonOnlyNullTest)
 	assert.NoError(t, err)
-	assert.True(t, mapper.IsNull)
+	assert.True(t, mapper.IsNull())
 }
 
 func removeWhiteSpaces(data string) string {
@@ -0,0 +1,125 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+var recordPositions = jogson.ParseOptions{RecordPositions: true}
+
+func TestParseWithOptions(t *testing.T) {
+	for _, data := range []string{jsonObjectTest, jsonObjectWithArrayTest, jsonObjectKeysPascalCaseTest, jsonObjectTimeTest} {
+		expected, err := jogson.NewObjectFromString(data)
+		assert.NoError(t, err)
+		actual, err := jogson.NewObjectFromStringWithOptions(data, jogson.ParseOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, expected.String(), actual.String())
+		assert.True(t, actual.Positions().IsEmpty())
+	}
+	for _, data := range []string{jsonAnyArrayTest, jsonArrayWithNullTest, json2DArrayTest, `["a\"bé😀", 1e3, -0.5]`} {
+		expected, err := jogson.NewArrayFromString(data)
+		assert.NoError(t, err)
+		actual, err := jogson.NewArrayFromStringWithOptions(data, jogson.ParseOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, expected.String(), actual.String())
+	}
+
+	object, err := jogson.NewObjectFromStringWithOptions(jsonObjectTest, jogson.ParseOptions{})
+	assert.NoError(t, err)
+	assert.True(t, object.GetArray("address").IsNull())
+
+	mapper, err := jogson.NewMapperFromStringWithOptions(`"test"`, jogson.ParseOptions{})
+	assert.NoError(t, err)
+	assert.True(t, mapper.IsString())
+}
+
+func TestParseWithOptionsInvalid(t *testing.T) {
+	tests := []struct {
+		json    string
+		message string
+		offset  int
+	}{
+		{`{"a": 1,}`, `invalid character '}' looking for beginning of object key string`, 8},
+		{`{"a" 1}`, `invalid character '1' after object key`, 5},
+		{`[1 2]`, `invalid character '2' after array element`, 3},
+		{`[tru]`, `invalid character ']' in literal true (expecting 'e')`, 4},
+		{`"a\x"`, `invalid character 'x' in string escape code`, 3},
+		{`01`, `invalid character '1' after top-level value`, 1},
+		{`{"a": [1, 2}`, `invalid character '}' after array element`, 11},
+		{`{"a": `, `unexpected end of JSON input`, 6},
+	}
+	for _, test := range tests {
+		_, err := jogson.NewMapperFromStringWithOptions(test.json, jogson.ParseOptions{})
+		var parseErr *jogson.ParseError
+		assert.ErrorAs(t, err, &parseErr, test.json)
+		assert.Equal(t, test.message, parseErr.Message, test.json)
+		assert.Equal(t, test.offset, parseErr.Offset, test.json)
+	}
+
+	_, err := jogson.NewObjectFromStringWithOptions(jsonAnyArrayTest, jogson.ParseOptions{})
+	assert.ErrorIs(t, err, jogson.ParseErr)
+	assert.Equal(t, "parse error: 1:1: cannot unmarshal array into object", err.Error())
+}
+
+func TestObjectPositions(t *testing.T) {
+	object, err := jogson.NewObjectFromStringWithOptions(jsonObjectPositionsTest, recordPositions)
+	assert.NoError(t, err)
+
+	span, ok := object.Position("name")
+	assert.True(t, ok)
+	assert.Equal(t, jogson.Position{Offset: 12, Line: 2, Column: 11}, span.Start)
+	assert.Equal(t, jogson.Position{Offset: 19, Line: 2, Column: 18}, span.End)
+	assert.Equal(t, `"Jason"`, jsonObjectPositionsTest[span.Start.Offset:span.End.Offset])
+
+	span, ok = object.KeyPosition("name")
+	assert.True(t, ok)
+	assert.Equal(t, 2, span.Start.Line)
+	assert.Equal(t, 3, span.Start.Column)
+
+	span, ok = object.Position("address")
+	assert.True(t, ok)
+	assert.Equal(t, 7, span.Start.Line)
+	assert.Equal(t, "null", jsonObjectPositionsTest[span.Start.Offset:span.End.Offset])
+
+	_, ok = object.Position("not found")
+	assert.False(t, ok)
+
+	child := object.GetArray("children").GetObject(1)
+	span, ok = child.Position("age")
+	assert.True(t, ok)
+	assert.Equal(t, 5, span.Start.Line)
+	assert.Equal(t, 29, span.Start.Column)
+	assert.Equal(t, "-1", jsonObjectPositionsTest[span.Start.Offset:span.End.Offset])
+
+	span, ok = object.GetArray("children").Position(0)
+	assert.True(t, ok)
+	assert.Equal(t, `{"name": "Rachel", "age": 15}`, jsonObjectPositionsTest[span.Start.Offset:span.End.Offset])
+
+	withoutPositions, err := jogson.NewObjectFromString(jsonObjectPositionsTest)
+	assert.NoError(t, err)
+	_, ok = withoutPositions.Position("name")
+	assert.False(t, ok)
+}
+
+func TestPositionsWithWalk(t *testing.T) {
+	mapper, err := jogson.NewMapperFromStringWithOptions(jsonObjectPositionsTest, recordPositions)
+	assert.NoError(t, err)
+	var lines []int
+	mapper.Walk(func(path jogson.Path, value jogson.JsonMapper) jogson.WalkAction {
+		if i, err := value.AsInt(); err == nil && i < 0 {
+			span, ok := mapper.Positions().Value(path)
+			assert.True(t, ok)
+			lines = append(lines, span.Start.Line)
+		}
+		return jogson.WalkContinue
+	})
+	assert.Equal(t, []int{5}, lines)
+
+	object, err := mapper.AsObject()
+	assert.NoError(t, err)
+	span, ok := object.Positions().Value(jogson.Path{"children", "1", "age"})
+	assert.True(t, ok)
+	assert.Equal(t, 5, span.Start.Line)
+}
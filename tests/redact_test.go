@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectRedact(t *testing.T) {
+	object, err := jogson.NewObjectFromString(jsonObjectRedactTest)
+	assert.NoError(t, err)
+	policy := jogson.NewRedactionPolicy(
+		jogson.RedactionRule{Keys: []string{"password"}, Mode: jogson.RedactRemove},
+		jogson.RedactionRule{KeyPatterns: []*regexp.Regexp{regexp.MustCompile(`_token$`)}, Mode: jogson.RedactReplace},
+		jogson.RedactionRule{Paths: []string{"$.cards[*].number"}, Mode: jogson.RedactMask, KeepLast: 4},
+		jogson.RedactionRule{Paths: []string{"$..cvv"}, Mode: jogson.RedactHash, HashKey: []byte("key")},
+		jogson.RedactionRule{Keys: []string{"credentials"}, Mode: jogson.RedactMask, Placeholder: "***"},
+	)
+	redacted := object.Redact(policy)
+
+	assert.False(t, redacted.Contains("Password"))
+	assert.Equal(t, "jason", redacted.GetString("user"))
+	auth := redacted.GetObject("auth")
+	assert.Equal(t, "[REDACTED]", auth.GetString("access_token"))
+	assert.Equal(t, "[REDACTED]", auth.GetString("refresh_token"))
+
+	cards := redacted.GetArray("cards")
+	assert.Equal(t, "************1111", cards.GetObject(0).GetString("number"))
+	assert.Equal(t, "************0004", cards.GetObject(1).GetString("number"))
+	mac := hmac.New(sha256.New, []byte("key"))
+	mac.Write([]byte("123"))
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), cards.GetObject(0).GetString("cvv"))
+	assert.Nil(t, cards.GetObject(1).GetStringN("cvv"))
+	assert.Equal(t, "***", redacted.GetString("credentials"))
+
+	// the original object is not changed
+	assert.Equal(t, "secret", object.GetString("Password"))
+	assert.Equal(t, "abc", object.GetObject("auth").GetString("access_token"))
+	assert.Equal(t, "4111111111111111", object.GetArray("cards").GetObject(0).GetString("number"))
+}
+
+func TestArrayRedact(t *testing.T) {
+	array, err := jogson.NewArrayFromString(`[{"token": "abc", "name": "Jason"}, {"nested": [{"token": "def"}]}, "token"]`)
+	assert.NoError(t, err)
+	policy := jogson.NewRedactionPolicy(jogson.RedactionRule{Keys: []string{"token"}, Mode: jogson.RedactReplace, Placeholder: "#hidden#"})
+	redacted := array.Redact(policy)
+	assert.Equal(t, `[{"name":"Jason","token":"#hidden#"},{"nested":[{"token":"#hidden#"}]},"token"]`, redacted.String())
+	assert.Equal(t, "abc", array.GetObject(0).GetString("token"))
+}
+
+func TestRedactKeysIgnoreArrayIndices(t *testing.T) {
+	policy := jogson.NewRedactionPolicy(jogson.RedactionRule{
+		Keys:        []string{"0"},
+		KeyPatterns: []*regexp.Regexp{regexp.MustCompile(`^1$`)},
+		Mode:        jogson.RedactRemove,
+	})
+	object, err := jogson.NewObjectFromString(`{"0": "secret", "1": "secret", "list": ["a", "b", "c"], "nested": [["x"]]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"list":["a","b","c"],"nested":[["x"]]}`, object.Redact(policy).String())
+
+	array, err := jogson.NewArrayFromString(`["a", "b", {"0": "secret"}]`)
+	assert.NoError(t, err)
+	assert.Equal(t, `["a","b",{}]`, array.Redact(policy).String())
+}
+
+func TestRedactShortValues(t *testing.T) {
+	object, err := jogson.NewObjectFromString(`{"cvv": "123", "pin": "7", "card": "4111111111111111"}`)
+	assert.NoError(t, err)
+	policy := jogson.NewRedactionPolicy(
+		jogson.RedactionRule{Keys: []string{"cvv", "pin"}, Mode: jogson.RedactMask, KeepLast: 4},
+		jogson.RedactionRule{Keys: []string{"card"}, Mode: jogson.RedactHash},
+	)
+	redacted := object.Redact(policy)
+	assert.Equal(t, "**3", redacted.GetString("cvv"))
+	assert.Equal(t, "*", redacted.GetString("pin"))
+
+	// without a key, a random key is used instead of a plain hash
+	plain := sha256.Sum256([]byte("4111111111111111"))
+	hash := redacted.GetString("card")
+	assert.Len(t, hash, 64)
+	assert.NotEqual(t, hex.EncodeToString(plain[:]), hash)
+	assert.NotEqual(t, hash, object.Redact(policy).GetString("card"))
+}
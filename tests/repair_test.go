@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepairJSON(t *testing.T) {
+	tests := []struct {
+		json     string
+		expected string
+	}{
+		{`{"name": "Jason", "age": 15}`, `{"name":"Jason","age":15}`},
+		{`{"name": "Jason", "children": ["Rachel", "Sa`, `{"name":"Jason","children":["Rachel","Sa"]}`},
+		{`{"a": 1, "b`, `{"a":1}`},
+		{`{"a": [1, 2}`, `{"a":[1,2]}`},
+		{`{'a': True, 'b': None, c: False}`, `{"a":true,"b":null,"c":false}`},
+		{"{\"a\": \"line\nbreak\"}", `{"a":"line\nbreak"}`},
+		{`{"a": 1 "b": 2,}`, `{"a":1,"b":2}`},
+		{`{"a": "he said "hi" twice"}`, `{"a":"he said \"hi\" twice"}`},
+		{`[1., +2, -, tr`, `[1,2,null,true]`},
+		{"Sure, here it is: {\"a\": 1} // done", `{"a":1}`},
+		{`[1, /* two */ 2] garbage`, `[1,2]`},
+	}
+	for _, test := range tests {
+		repaired, _, err := jogson.RepairJSON([]byte(test.json))
+		assert.NoError(t, err, test.json)
+		assert.Equal(t, test.expected, string(repaired), test.json)
+	}
+
+	_, _, err := jogson.RepairJSON([]byte("no json here"))
+	assert.ErrorIs(t, err, jogson.ParseErr)
+	_, _, err = jogson.RepairJSON([]byte(" "))
+	assert.ErrorIs(t, err, jogson.ParseErr)
+}
+
+func TestNewMapperLenient(t *testing.T) {
+	mapper, repairs, err := jogson.NewMapperFromStringLenient(`{"name": "Jason", "age": 15, "children": [{"name": "Rachel"}, {"name": "Sa`)
+	assert.NoError(t, err)
+	object, err := mapper.AsObject()
+	assert.NoError(t, err)
+	assert.Equal(t, "Sa", object.GetArray("children").GetObject(1).GetString("name"))
+	assert.Equal(t, []jogson.Repair{
+		{Offset: 74, Message: "added missing closing quote"},
+		{Offset: 74, Message: "added missing '}'"},
+		{Offset: 74, Message: "added missing ']'"},
+		{Offset: 74, Message: "added missing '}'"},
+	}, repairs)
+
+	mapper, repairs, err = jogson.NewMapperFromStringLenient(jsonObjectTest)
+	assert.NoError(t, err)
+	assert.Empty(t, repairs)
+	assert.True(t, mapper.IsObject())
+}
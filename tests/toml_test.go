@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewObjectFromTOMLFile(t *testing.T) {
+	object, err := jogson.NewObjectFromTOMLFile("files/test_config.toml")
+	assert.NoError(t, err)
+	assert.Equal(t, "TOML Example", object.GetString("title"))
+	assert.Equal(t, 1024, object.GetInt("version"))
+	owner := object.GetObject("owner")
+	assert.Equal(t, "1979-05-27T07:32:00-08:00", owner.GetString("dob"))
+	assert.Equal(t, 1979, owner.GetTime("dob").Year())
+	assert.NoError(t, owner.LastError)
+
+	database := object.GetObject("database")
+	assert.True(t, database.GetBool("enabled"))
+	assert.Equal(t, 3, database.GetArray("ports").Length())
+	assert.Equal(t, "phi", database.GetArray("data").GetArray(0).GetString(1))
+	assert.Equal(t, 79.5, database.GetObject("temp_targets").GetFloat("cpu"))
+	assert.Equal(t, "frontend", object.GetObject("servers").GetObject("alpha").GetString("role"))
+
+	products := object.GetArray("products")
+	assert.Equal(t, 2, products.Length())
+	assert.Equal(t, "gray", products.GetObject(1).GetString("color"))
+}
+
+func TestNewObjectFromTOML(t *testing.T) {
+	tomlData := `
+site."google.com" = true
+a.b.c = 1
+a.b.d = 'C:\Users'
+hex = 0xDEAD_BEEF
+float = -1.5e3
+lines = """
+Roses are red\
+  Violets are blue"""
+raw = '''a "quoted" \n'''
+local = 1979-05-27T07:32:00
+date = 1979-05-27
+time = 07:32:00.999
+
+[[fruits]]
+name = "apple"
+[fruits.physical]
+color = "red"
+[[fruits.varieties]]
+name = "red delicious"
+`
+	object, err := jogson.NewObjectFromTOML([]byte(tomlData))
+	assert.NoError(t, err)
+	assert.True(t, object.GetObject("site").GetBool("google.com"))
+	assert.Equal(t, 1, object.GetObject("a").GetObject("b").GetInt("c"))
+	assert.Equal(t, `C:\Users`, object.GetObject("a").GetObject("b").GetString("d"))
+	assert.Equal(t, 3735928559, object.GetInt("hex"))
+	assert.Equal(t, -1500.0, object.GetFloat("float"))
+	assert.Equal(t, "Roses are redViolets are blue", object.GetString("lines"))
+	assert.Equal(t, `a "quoted" \n`, object.GetString("raw"))
+	assert.Equal(t, "1979-05-27 07:32:00", object.GetString("local"))
+	assert.Equal(t, 32, object.GetTime("local").Minute())
+	assert.Equal(t, "1979-05-27", object.GetString("date"))
+	assert.Equal(t, "07:32:00.999", object.GetString("time"))
+	fruit := object.GetArray("fruits").GetObject(0)
+	assert.Equal(t, "red", fruit.GetObject("physical").GetString("color"))
+	assert.Equal(t, "red delicious", fruit.GetArray("varieties").GetObject(0).GetString("name"))
+}
+
+func TestNewObjectFromTOMLFails(t *testing.T) {
+	tests := []struct {
+		toml    string
+		message string
+	}{
+		{"a = 1\na = 2", "2:1: key 'a' is already defined"},
+		{"[a]\n[a]", "2:1: table 'a' is already defined"},
+		{"a = 1\n[a.b]", "2:1: key 'a' is already defined as a value"},
+		{"a = { b = 1 }\n[a]", "2:1: table 'a' is already defined"},
+		{"a = 1 b = 2", "1:7: invalid character 'b', expected newline"},
+		{"a = 01", "1:5: invalid number 01"},
+		{"a = 1__0", "1:5: invalid number 1__0"},
+		{"a = inf", "1:5: inf cannot be represented in JSON"},
+		{"a = 1979-13-27", "1:5: invalid datetime 1979-13-27"},
+		{`a = "\q"`, `1:7: invalid character 'q' in string escape code`},
+		{"a = \"open", "1:10: unexpected end of TOML input"},
+		{"a = [1, 2", "1:10: unexpected end of TOML input"},
+		{"= 1", "1:1: invalid character '=' in key"},
+	}
+	for _, test := range tests {
+		_, err := jogson.NewObjectFromTOML([]byte(test.toml))
+		assert.ErrorIs(t, err, jogson.ParseErr, test.toml)
+		if err != nil {
+			assert.Equal(t, "parse error: "+test.message, err.Error(), test.toml)
+		}
+	}
+}
+
+func TestTOMLString(t *testing.T) {
+	object, err := jogson.NewObjectFromString(`{
+		"title": "Example",
+		"version": 2,
+		"ratio": 0.5,
+		"tags": ["a", "b"],
+		"owner": {"name": "Tom", "address": {"city": "Berlin"}},
+		"servers": {"alpha": {"ip": "10.0.0.1"}},
+		"products": [{"name": "Hammer"}, {"name": "Nail", "dimensions": {"length": 2}}],
+		"points": [{"x": 1}, 2],
+		"with space": "quoted key"
+	}`)
+	assert.NoError(t, err)
+	expected := `points = [{ x = 1 }, 2]
+ratio = 0.5
+tags = ["a", "b"]
+title = "Example"
+version = 2
+"with space" = "quoted key"
+
+[owner]
+name = "Tom"
+
+[owner.address]
+city = "Berlin"
+
+[servers.alpha]
+ip = "10.0.0.1"
+
+[[products]]
+name = "Hammer"
+
+[[products]]
+name = "Nail"
+
+[products.dimensions]
+length = 2
+`
+	tomlString, err := object.TOMLString()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, tomlString)
+
+	roundTrip, err := jogson.NewObjectFromTOML([]byte(tomlString))
+	assert.NoError(t, err)
+	assert.Equal(t, object.String(), roundTrip.String())
+
+	object.AddNull("missing")
+	_, err = object.TOMLString()
+	assert.ErrorIs(t, err, jogson.TOMLConversionErr)
+	assert.True(t, strings.HasSuffix(err.Error(), "'/missing': null cannot be represented in TOML"))
+}
+
+func TestTOMLStringSpecialFloats(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{math.NaN(), "nan"},
+		{math.Inf(1), "inf"},
+		{math.Inf(-1), "-inf"},
+	}
+	for _, test := range tests {
+		object := jogson.EmptyObject()
+		object.AddFloat("a", test.value)
+		tomlString, err := object.TOMLString()
+		assert.NoError(t, err)
+		assert.Equal(t, "a = "+test.expected+"\n", tomlString)
+
+		// the output is valid TOML, but the value cannot be read back into JSON
+		_, err = jogson.NewObjectFromTOML([]byte(tomlString))
+		assert.ErrorIs(t, err, jogson.ParseErr)
+		assert.EqualError(t, err, "parse error: 1:5: "+test.expected+" cannot be represented in JSON")
+	}
+}
@@ -0,0 +1,195 @@
+package tests
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPath(t *testing.T) {
+	path, err := jogson.ParsePath("/a~1b/c~0d/0")
+	assert.NoError(t, err)
+	assert.Equal(t, jogson.Path{"a/b", "c~d", "0"}, path)
+	assert.Equal(t, "/a~1b/c~0d/0", path.String())
+	assert.Equal(t, "0", path.Last())
+	assert.Equal(t, "/a~1b/c~0d", path.Parent().String())
+	assert.Equal(t, "/a~1b/c~0d/0/name", path.AppendKey("name").String())
+	assert.Equal(t, "/a~1b/c~0d/0/1", path.AppendIndex(1).String())
+
+	root, err := jogson.ParsePath("")
+	assert.NoError(t, err)
+	assert.Equal(t, "", root.String())
+
+	_, err = jogson.ParsePath("a/b")
+	assert.ErrorIs(t, err, jogson.InvalidPathErr)
+}
+
+func TestObjectWalk(t *testing.T) {
+	object, err := jogson.NewObjectFromString(jsonObjectWalkTest)
+	assert.NoError(t, err)
+	var paths []string
+	object.Walk(func(path jogson.Path, value jogson.JsonMapper) jogson.WalkAction {
+		paths = append(paths, path.String())
+		return jogson.WalkContinue
+	})
+	expected := []string{
+		"", "/a~1b", "/a~1b/c~0d", "/address", "/children",
+		"/children/0", "/children/0/age", "/children/0/name",
+		"/children/1", "/children/1/age", "/children/1/name", "/name",
+	}
+	assert.Equal(t, expected, paths)
+}
+
+func TestObjectWalkSkipAndStop(t *testing.T) {
+	object, err := jogson.NewObjectFromString(jsonObjectWalkTest)
+	assert.NoError(t, err)
+	var paths []string
+	object.Walk(func(path jogson.Path, value jogson.JsonMapper) jogson.WalkAction {
+		paths = append(paths, path.String())
+		if value.IsArray() {
+			return jogson.WalkSkip
+		}
+		return jogson.WalkContinue
+	})
+	assert.Equal(t, []string{"", "/a~1b", "/a~1b/c~0d", "/address", "/children", "/name"}, paths)
+
+	var names []string
+	object.Walk(func(path jogson.Path, value jogson.JsonMapper) jogson.WalkAction {
+		if path.Last() == "name" {
+			name, err := value.AsString()
+			assert.NoError(t, err)
+			names = append(names, name)
+			return jogson.WalkStop
+		}
+		return jogson.WalkContinue
+	})
+	assert.Equal(t, []string{"Rachel"}, names)
+}
+
+func TestArrayWalk(t *testing.T) {
+	array, err := jogson.NewArrayFromString(jsonArrayWithNullTest)
+	assert.NoError(t, err)
+	nulls := 0
+	var paths []string
+	array.Walk(func(path jogson.Path, value jogson.JsonMapper) jogson.WalkAction {
+		paths = append(paths, path.String())
+		if value.IsNull() {
+			nulls++
+		}
+		return jogson.WalkContinue
+	})
+	assert.Equal(t, []string{"", "/0", "/0/name", "/1", "/1/name", "/2", "/3"}, paths)
+	assert.Equal(t, 1, nulls)
+}
+
+func TestMapperWalk(t *testing.T) {
+	mapper, err := jogson.NewMapperFromString(jsonOnlyIntTest)
+	assert.NoError(t, err)
+	count := 0
+	mapper.Walk(func(path jogson.Path, value jogson.JsonMapper) jogson.WalkAction {
+		count++
+		assert.Equal(t, "", path.String())
+		assert.Equal(t, "56", value.String())
+		return jogson.WalkContinue
+	})
+	assert.Equal(t, 1, count)
+}
+
+func TestObjectTransformValues(t *testing.T) {
+	object, err := jogson.NewObjectFromString(jsonObjectWalkTest)
+	assert.NoError(t, err)
+	object.TransformValues(func(path jogson.Path, value jogson.JsonMapper) (jogson.JsonMapper, jogson.TransformAction) {
+		switch {
+		case value.IsNull():
+			return value, jogson.TransformDelete
+		case path.Last() == "age":
+			age, err := value.AsInt()
+			assert.NoError(t, err)
+			newAge, err := jogson.NewMapperFromString(strconv.Itoa(age + 1))
+			assert.NoError(t, err)
+			return newAge, jogson.TransformReplace
+		case path.String() == "/children/1":
+			return value, jogson.TransformDelete
+		}
+		return value, jogson.TransformKeep
+	})
+	assert.False(t, object.Contains("address"))
+	children := object.GetArray("children")
+	assert.Equal(t, 1, children.Length())
+	assert.Equal(t, 16, children.GetObject(0).GetInt("age"))
+	assert.Equal(t, "Jason", object.GetString("name"))
+}
+
+func TestArrayTransformValues(t *testing.T) {
+	array, err := jogson.NewArrayFromString(jsonArrayWithNullTest)
+	assert.NoError(t, err)
+	array.TransformValues(func(path jogson.Path, value jogson.JsonMapper) (jogson.JsonMapper, jogson.TransformAction) {
+		if value.IsNull() {
+			return value, jogson.TransformDelete
+		}
+		if value.IsString() {
+			s, _ := value.AsString()
+			newValue, err := jogson.NewMapperFromValue(s + "!")
+			assert.NoError(t, err)
+			return newValue, jogson.TransformReplace
+		}
+		return value, jogson.TransformKeep
+	})
+	assert.Equal(t, `[{"name":"Jason!"},{"name":"Chris!"},"string!"]`, array.String())
+}
+
+func TestTransformValuesReplaceWithNull(t *testing.T) {
+	null, err := jogson.NewMapperFromValue(nil)
+	assert.NoError(t, err)
+	replaceStrings := func(path jogson.Path, value jogson.JsonMapper) (jogson.JsonMapper, jogson.TransformAction) {
+		if value.IsString() {
+			return null, jogson.TransformReplace
+		}
+		return value, jogson.TransformKeep
+	}
+
+	// replaced values behave like parsed nulls
+	array, err := jogson.NewArrayFromString(`["a", 1]`)
+	assert.NoError(t, err)
+	array.TransformValues(replaceStrings)
+	parsedArray, err := jogson.NewArrayFromString(`[null, 1]`)
+	assert.NoError(t, err)
+	assert.Equal(t, parsedArray.String(), array.String())
+	array.GetObject(0)
+	parsedArray.GetObject(0)
+	assert.Equal(t, parsedArray.LastError, array.LastError)
+
+	object, err := jogson.NewObjectFromString(`{"a": "b", "c": 1}`)
+	assert.NoError(t, err)
+	object.TransformValues(replaceStrings)
+	parsedObject, err := jogson.NewObjectFromString(`{"a": null, "c": 1}`)
+	assert.NoError(t, err)
+	assert.Equal(t, parsedObject.String(), object.String())
+	object.GetObject("a")
+	parsedObject.GetObject("a")
+	assert.Equal(t, parsedObject.LastError, object.LastError)
+}
+
+func TestWalkRootKind(t *testing.T) {
+	object, err := jogson.NewObjectFromString(`{"a": [1]}`)
+	assert.NoError(t, err)
+	kinds := make(map[string]jogson.Kind)
+	object.Walk(func(path jogson.Path, value jogson.JsonMapper) jogson.WalkAction {
+		kinds[path.String()] = value.Kind()
+		return jogson.WalkContinue
+	})
+	assert.Equal(t, map[string]jogson.Kind{"": jogson.Object, "/a": jogson.Array, "/a/0": jogson.Number}, kinds)
+
+	array, err := jogson.NewArrayFromString(`[{"a": 1}]`)
+	assert.NoError(t, err)
+	var rootIsArray bool
+	array.Walk(func(path jogson.Path, value jogson.JsonMapper) jogson.WalkAction {
+		if len(path) == 0 {
+			rootIsArray = value.IsArray()
+		}
+		return jogson.WalkContinue
+	})
+	assert.True(t, rootIsArray)
+}
@@ -36,29 +36,26 @@ func TestWriteStringToObject(t *testing.T) {
 func TestWriteIntToObject(t *testing.T) {
 	obj := jogson.EmptyObject()
 	obj.AddInt("int", 2)
-	objElements := obj.Elements()
-	assert.Equal(t, 2, objElements["int"].AsInt)
+	assert.True(t, obj.Get("int").IsInt())
+	assert.Equal(t, 2, obj.GetInt("int"))
 }
 
 func TestWriteArrayStringToObject(t *testing.T) {
 	obj := jogson.EmptyObject()
 	obj.AddStringArray("strings", []string{"string1", "string2", "string4"})
-	objElements := obj.Elements()
-
-	stringArray := objElements["strings"].AsArray
-	arrayElements := stringArray.Elements()
-
-	assert.True(t, objElements["strings"].IsArray)
+	mapper := obj.Get("strings")
+	assert.True(t, mapper.IsArray())
+	stringArray, _ := mapper.AsArray()
 	assert.Equal(t, 3, stringArray.Length())
-	assert.Equal(t, "string1", arrayElements[0].AsString)
-	assert.Equal(t, "string4", arrayElements[2].AsString)
+	assert.Equal(t, "string1", stringArray.GetString(0))
+	assert.Equal(t, "string4", stringArray.GetString(2))
 }
 
 func TestWriteFloatToObject(t *testing.T) {
 	obj := jogson.EmptyObject()
 	obj.AddFloat("float", 2.5)
-	objElements := obj.Elements()
-	assert.Equal(t, 2.5, objElements["float"].AsFloat)
+	assert.True(t, obj.Get("float").IsFloat())
+	assert.Equal(t, 2.5, obj.GetFloat("float"))
 }
 
 func TestWriteBoolToObject(t *testing.T) {
@@ -70,43 +67,34 @@ func TestWriteBoolToObject(t *testing.T) {
 func TestWriteArrayStringsToObject(t *testing.T) {
 	obj := jogson.EmptyObject()
 	obj.AddStringArray("strings", []string{"string1", "string2", "string4"})
-	objElements := obj.Elements()
-
-	stringArray := objElements["strings"].AsArray
-	arrayElements := stringArray.Elements()
-
-	assert.True(t, objElements["strings"].IsArray)
+	mapper := obj.Get("strings")
+	assert.True(t, mapper.IsArray())
+	stringArray, _ := mapper.AsArray()
 	assert.Equal(t, 3, stringArray.Length())
-	assert.Equal(t, "string1", arrayElements[0].AsString)
-	assert.Equal(t, "string4", arrayElements[2].AsString)
+	assert.Equal(t, "string1", stringArray.GetString(0))
+	assert.Equal(t, "string4", stringArray.GetString(2))
 }
 
 func TestWriteObjectArrayInt(t *testing.T) {
 	obj := jogson.EmptyObject()
 	obj.AddIntArray("numbers", []int{1, 2, 4})
-	objElements := obj.Elements()
-
-	numberArray := objElements["numbers"].AsArray
-	arrayElements := numberArray.Elements()
-
-	assert.True(t, objElements["numbers"].IsArray)
+	mapper := obj.Get("numbers")
+	assert.True(t, mapper.IsArray())
+	numberArray, _ := mapper.AsArray()
 	assert.Equal(t, 3, numberArray.Length())
-	assert.Equal(t, 1, arrayElements[0].AsInt)
-	assert.Equal(t, 4, arrayElements[2].AsInt)
+	assert.Equal(t, 1, numberArray.GetInt(0))
+	assert.Equal(t, 4, numberArray.GetInt(2))
 }
 
 func TestWriteArrayFloatToObject(t *testing.T) {
 	obj := jogson.EmptyObject()
 	obj.AddFloatArray("numbers", []float64{1.5, 2.0, 4.2})
-	objElements := obj.Elements()
-
-	numberArray := objElements["numbers"].AsArray
-	arrayElements := numberArray.Elements()
-
-	assert.True(t, objElements["numbers"].IsArray)
+	mapper := obj.Get("numbers")
+	assert.True(t, mapper.IsArray())
+	numberArray, _ := mapper.AsArray()
 	assert.Equal(t, 3, numberArray.Length())
-	assert.Equal(t, 1.5, arrayElements[0].AsFloat)
-	assert.Equal(t, 4.2, arrayElements[2].AsFloat)
+	assert.Equal(t, 1.5, numberArray.GetFloat(0))
+	assert.Equal(t, 4.2, numberArray.GetFloat(2))
 }
 
 func TestWriteScalarToArray(t *testing.T) {
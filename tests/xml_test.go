@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewObjectFromXMLFile(t *testing.T) {
+	object, err := jogson.NewObjectFromXMLFile("files/test_soap.xml", jogson.XMLOptions{})
+	assert.NoError(t, err)
+	envelope := object.GetObject("soap:Envelope")
+	assert.Equal(t, "http://schemas.xmlsoap.org/soap/envelope/", envelope.GetString("@xmlns:soap"))
+	response := envelope.GetObject("soap:Body").GetObject("m:GetStockPriceResponse")
+	price := response.GetObject("m:Price")
+	assert.Equal(t, "USD", price.GetString("@currency"))
+	assert.Equal(t, "34.5", price.GetString("#text"))
+	assert.Equal(t, "IBM", response.GetString("m:Symbol"))
+	assert.Equal(t, []string{"NYSE", "NASDAQ"}, response.GetArray("m:Exchange").AsStringArray())
+	assert.Equal(t, "<b>closed</b> & settled", response.GetString("m:Note"))
+}
+
+func TestNewObjectFromXML(t *testing.T) {
+	xmlData := `<order id="1"><item>apple</item><empty/><note>  spaced  </note></order>`
+	object, err := jogson.NewObjectFromXML([]byte(xmlData), jogson.XMLOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"order":{"@id":"1","empty":"","item":"apple","note":"  spaced  "}}`, object.String())
+
+	object, err = jogson.NewObjectFromXML([]byte(xmlData), jogson.XMLOptions{ForceArray: []string{"item"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"apple"}, object.GetObject("order").GetArray("item").AsStringArray())
+
+	tests := []struct {
+		xml     string
+		message string
+	}{
+		{"<a><b></a>", "parse error: 1:7: unexpected end element </a>"},
+		{"<a></a><b></b>", "parse error: 1:8: multiple root elements"},
+		{"<a>", "parse error: 1:4: unexpected end of XML input"},
+		{"text", "parse error: 1:1: text outside of the root element"},
+		{"", "parse error: 1:1: no root element"},
+	}
+	for _, test := range tests {
+		_, err = jogson.NewObjectFromXML([]byte(test.xml), jogson.XMLOptions{})
+		assert.ErrorIs(t, err, jogson.ParseErr, test.xml)
+		if err != nil {
+			assert.Equal(t, test.message, err.Error(), test.xml)
+		}
+	}
+}
+
+func TestToXML(t *testing.T) {
+	object, err := jogson.NewObjectFromString(`{"order": {"@id": 1, "items": {"item": ["apple", "pear & plum"]}, "note": {"@lang": "en", "#text": "fast"}, "gift": null, "total": 2.5}}`)
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	err = object.ToXML(&buf, jogson.XMLOptions{Indent: "  "})
+	assert.NoError(t, err)
+	expected := `<order id="1">
+  <gift/>
+  <items>
+    <item>apple</item>
+    <item>pear &amp; plum</item>
+  </items>
+  <note lang="en">fast</note>
+  <total>2.5</total>
+</order>
+`
+	assert.Equal(t, expected, buf.String())
+
+	roundTrip, err := jogson.NewObjectFromXML(buf.Bytes(), jogson.XMLOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "pear & plum", roundTrip.GetObject("order").GetObject("items").GetArray("item").GetString(1))
+
+	buf.Reset()
+	err = object.GetObject("order").GetObject("note").ToXML(&buf, jogson.XMLOptions{})
+	assert.ErrorIs(t, err, jogson.XMLConversionErr)
+	object, err = jogson.NewObjectFromString(`{"a": {"first name": "Jason"}}`)
+	assert.NoError(t, err)
+	err = object.ToXML(&buf, jogson.XMLOptions{})
+	assert.ErrorIs(t, err, jogson.XMLConversionErr)
+	assert.EqualError(t, err, "XML conversion error: '/a/first name': 'first name' is not a valid XML name")
+
+	object, err = jogson.NewObjectFromString(`{"item": ["apple", "pear"]}`)
+	assert.NoError(t, err)
+	buf.Reset()
+	err = object.ToXML(&buf, jogson.XMLOptions{})
+	assert.ErrorIs(t, err, jogson.XMLConversionErr)
+	assert.Empty(t, buf.String())
+}
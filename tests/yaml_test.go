@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rmordechay/jogson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewObjectFromYAML(t *testing.T) {
+	yamlData := `
+defaults: &defaults
+  adapter: postgres
+  port: 5432
+development:
+  <<: *defaults
+  port: 5433
+  debug: yes
+ports: [0x1F, 1_000, 1.5e3]
+created: 2024-10-06
+nothing: ~
+1: one
+true: "true"
+`
+	object, err := jogson.NewObjectFromYAML([]byte(yamlData))
+	assert.NoError(t, err)
+	development := object.GetObject("development")
+	assert.Equal(t, "postgres", development.GetString("adapter"))
+	assert.Equal(t, 5433, development.GetInt("port"))
+	assert.Equal(t, "yes", development.GetString("debug"))
+	assert.Equal(t, []int{31, 1000, 1500}, object.GetArray("ports").AsIntArray())
+	assert.Equal(t, "2024-10-06", object.GetString("created"))
+	assert.True(t, object.Get("nothing").IsNull())
+	assert.Equal(t, "one", object.GetString("1"))
+	assert.Equal(t, "true", object.GetString("true"))
+
+	_, err = jogson.NewObjectFromYAML([]byte("- a\n- b"))
+	assert.ErrorIs(t, err, jogson.TypeConversionErr)
+}
+
+func TestNewMapperFromYAMLFile(t *testing.T) {
+	mapper, err := jogson.NewMapperFromYAMLFile("files/test_manifest.yaml")
+	assert.NoError(t, err)
+	assert.True(t, mapper.IsArray())
+	documents, err := mapper.AsArray()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, documents.Length())
+	assert.Equal(t, "debug", documents.GetObject(0).GetObject("data").GetString("LOG_LEVEL"))
+	deployment := documents.GetObject(1)
+	assert.Equal(t, "web", deployment.GetObject("spec").GetObject("selector").GetObject("matchLabels").GetString("app"))
+	containers := deployment.GetObject("spec").GetObject("template").GetObject("spec").GetArray("containers")
+	assert.Equal(t, "nginx:1.25", containers.GetObject(0).GetString("image"))
+
+	_, err = jogson.NewMapperFromYAMLFile("files/not_found.yaml")
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "invalid.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("a: [1, 2"), 0644))
+	_, err = jogson.NewMapperFromYAMLFile(path)
+	assert.ErrorIs(t, err, jogson.ParseErr)
+	var parseErr *jogson.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, path, parseErr.Path)
+	assert.Contains(t, err.Error(), path)
+}
+
+func TestYAMLAliasBudget(t *testing.T) {
+	var builder strings.Builder
+	builder.WriteString("a0: &a0 [x, x, x, x, x, x, x, x, x, x]\n")
+	for i := 1; i < 10; i++ {
+		fmt.Fprintf(&builder, "a%d: &a%d [*a%d, *a%d, *a%d, *a%d, *a%d, *a%d, *a%d, *a%d, *a%d, *a%d]\n", i, i,
+			i-1, i-1, i-1, i-1, i-1, i-1, i-1, i-1, i-1, i-1)
+	}
+	_, err := jogson.NewMapperFromYAML([]byte(builder.String()))
+	assert.ErrorIs(t, err, jogson.YAMLConversionErr)
+
+	object, err := jogson.NewObjectFromYAML([]byte("a: &a [1, 2]\nb: [*a, *a]"))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":[1,2],"b":[[1,2],[1,2]]}`, object.String())
+}
+
+func TestYAMLConversionErrors(t *testing.T) {
+	tests := []string{
+		"a: .inf",
+		"a: .nan",
+		"? [a, b]\n: c",
+		"a: !!int abc",
+	}
+	for _, test := range tests {
+		_, err := jogson.NewMapperFromYAML([]byte(test))
+		assert.ErrorIs(t, err, jogson.YAMLConversionErr, test)
+	}
+	_, err := jogson.NewMapperFromYAML([]byte("a: [1, 2"))
+	assert.ErrorIs(t, err, jogson.ParseErr)
+
+	_, err = jogson.NewMapperFromYAML([]byte("a: 1\n---\nb: 2\n  c: 3\n"))
+	var parseErr *jogson.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 4, parseErr.Line)
+	assert.Equal(t, 1, parseErr.Column)
+	assert.Equal(t, 14, parseErr.Offset)
+	assert.EqualError(t, err, "parse error: 4:1: mapping values are not allowed in this context")
+
+	mapper, err := jogson.NewMapperFromYAML([]byte(""))
+	assert.NoError(t, err)
+	assert.True(t, mapper.IsNull())
+}
+
+func TestYAMLString(t *testing.T) {
+	object, err := jogson.NewObjectFromString(`{"name": "Jason", "age": 15, "active": "true", "children": [{"name": "Rachel"}], "spouse": null}`)
+	assert.NoError(t, err)
+	expected := `active: "true"
+age: 15
+children:
+  - name: Rachel
+name: Jason
+spouse: null
+`
+	assert.Equal(t, expected, object.YAMLString())
+	array, err := jogson.NewArrayFromString(`[1, "a", 2.5]`)
+	assert.NoError(t, err)
+	assert.Equal(t, "- 1\n- a\n- 2.5\n", array.YAMLString())
+	mapper, err := jogson.NewMapperFromString(`"text"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "text\n", mapper.YAMLString())
+
+	roundTrip, err := jogson.NewObjectFromYAML([]byte(object.YAMLString()))
+	assert.NoError(t, err)
+	assert.Equal(t, object.String(), roundTrip.String())
+}
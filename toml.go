@@ -0,0 +1,935 @@
+package jogson
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// tomlDateTimeRegex matches TOML offset datetimes, local datetimes, local dates and local times
+var tomlDateTimeRegex = regexp.MustCompile(
+	`^(?:(\d{4}-\d{2}-\d{2})(?:[Tt ](\d{2}:\d{2}:\d{2}(?:\.\d+)?)([Zz]|[+-]\d{2}:\d{2})?)?|(\d{2}:\d{2}:\d{2}(?:\.\d+)?))`)
+
+// NewObjectFromTOML parses a TOML document into a JsonObject. Tables and inline tables are converted into
+// objects and arrays of tables into arrays of objects. Datetimes are converted into strings that GetTime
+// understands: offset datetimes into RFC 3339, e.g. "1979-05-27T07:32:00Z", local datetimes into
+// "1979-05-27 07:32:00", local dates into "1979-05-27" and local times into "07:32:00". inf and nan
+// cannot be represented as JSON and return ParseErr like syntax errors.
+func NewObjectFromTOML(data []byte) (*JsonObject, error) {
+	p := &tomlParser{data: data, root: newTOMLTable()}
+	p.current = p.root
+	err := p.parseDocument()
+	if err != nil {
+		return &JsonObject{}, err
+	}
+	return newObjectFromMap(membersToPtrs(p.root.toMap())), nil
+}
+
+// NewObjectFromTOMLFile reads a TOML file from the given path and parses it into a JsonObject. See
+// NewObjectFromTOML.
+func NewObjectFromTOMLFile(path string) (*JsonObject, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return &JsonObject{}, err
+	}
+	obj, err := NewObjectFromTOML(file)
+	return obj, withParseErrorPath(err, path)
+}
+
+// TOMLString returns a TOML representation of the JsonObject. The output is deterministic: keys are sorted,
+// values are written before tables, and tables before arrays of tables. Arrays whose elements are all
+// objects are written as arrays of tables and other nested objects as inline tables. NaN and infinities are
+// written as nan, inf and -inf. Since TOML has no null, TOMLConversionErr is returned if the object contains
+// null values.
+func (o *JsonObject) TOMLString() (string, error) {
+	var sb strings.Builder
+	err := writeTOMLTable(&sb, nil, Path{}, o.object)
+	if err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// tomlTable is a table while parsing TOML. Tables are converted into maps when parsing is finished.
+type tomlTable struct {
+	members map[string]any
+	// defined is set for tables defined by a header or a dotted key, which cannot be defined again
+	defined bool
+	// dotted is set for tables defined by a dotted key, which can be extended by other dotted keys
+	dotted bool
+}
+
+// tomlTableArray is an array of tables while parsing TOML
+type tomlTableArray struct {
+	tables []*tomlTable
+}
+
+func newTOMLTable() *tomlTable {
+	return &tomlTable{members: make(map[string]any)}
+}
+
+// toMap converts the table and all tables it contains into maps
+func (t *tomlTable) toMap() map[string]any {
+	members := make(map[string]any, len(t.members))
+	for k, v := range t.members {
+		switch value := v.(type) {
+		case *tomlTable:
+			members[k] = value.toMap()
+		case *tomlTableArray:
+			tables := make([]any, len(value.tables))
+			for i, table := range value.tables {
+				tables[i] = table.toMap()
+			}
+			members[k] = tables
+		default:
+			members[k] = v
+		}
+	}
+	return members
+}
+
+// tomlParser parses TOML 1.0 documents
+type tomlParser struct {
+	data    []byte
+	offset  int
+	root    *tomlTable
+	current *tomlTable
+}
+
+func (p *tomlParser) parseDocument() error {
+	if bytes.HasPrefix(p.data, []byte("\ufeff")) {
+		p.offset = len("\ufeff")
+	}
+	for {
+		p.skipWhitespace()
+		if p.offset >= len(p.data) {
+			return nil
+		}
+		var err error
+		switch p.data[p.offset] {
+		case '#', '\n', '\r':
+		case '[':
+			err = p.parseHeader()
+		default:
+			err = p.parseKeyValue(p.current)
+		}
+		if err == nil {
+			err = p.parseLineEnd()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// parseLineEnd parses optional whitespace and a comment followed by a newline or the end of the input
+func (p *tomlParser) parseLineEnd() error {
+	p.skipWhitespace()
+	if p.offset < len(p.data) && p.data[p.offset] == '#' {
+		err := p.skipComment()
+		if err != nil {
+			return err
+		}
+	}
+	switch {
+	case p.offset >= len(p.data):
+		return nil
+	case p.data[p.offset] == '\n':
+		p.offset++
+		return nil
+	case p.hasPrefix("\r\n"):
+		p.offset += 2
+		return nil
+	}
+	return p.errorf("invalid character %v, expected newline", p.currentChar())
+}
+
+// parseHeader parses a [table] or [[array of tables]] header and makes its table the current table
+func (p *tomlParser) parseHeader() error {
+	start := p.offset
+	isArray := p.hasPrefix("[[")
+	closing := "]"
+	if isArray {
+		closing = "]]"
+	}
+	p.offset += len(closing)
+	p.skipWhitespace()
+	keys, err := p.parseDottedKey()
+	if err != nil {
+		return err
+	}
+	if !p.hasPrefix(closing) {
+		if p.offset >= len(p.data) {
+			return p.unexpectedEnd()
+		}
+		return p.errorf("invalid character %v in table header, expected '%v'", p.currentChar(), closing)
+	}
+	p.offset += len(closing)
+	table, err := p.defineTable(keys, isArray, start)
+	if err != nil {
+		return err
+	}
+	p.current = table
+	return nil
+}
+
+// defineTable returns the table defined by a header with the given keys, creating all missing tables
+func (p *tomlParser) defineTable(keys []string, isArray bool, offset int) (*tomlTable, error) {
+	t := p.root
+	for _, key := range keys[:len(keys)-1] {
+		existing, ok := t.members[key]
+		if !ok {
+			table := newTOMLTable()
+			t.members[key] = table
+			t = table
+			continue
+		}
+		switch v := existing.(type) {
+		case *tomlTable:
+			t = v
+		case *tomlTableArray:
+			t = v.tables[len(v.tables)-1]
+		default:
+			return nil, p.errorAt(offset, "key '%v' is already defined as a value", key)
+		}
+	}
+
+	last := keys[len(keys)-1]
+	existing, ok := t.members[last]
+	if isArray {
+		if !ok {
+			existing = &tomlTableArray{}
+			t.members[last] = existing
+		}
+		tableArray, isTableArray := existing.(*tomlTableArray)
+		if !isTableArray {
+			return nil, p.errorAt(offset, "key '%v' is already defined", strings.Join(keys, "."))
+		}
+		table := newTOMLTable()
+		table.defined = true
+		tableArray.tables = append(tableArray.tables, table)
+		return table, nil
+	}
+	if !ok {
+		table := newTOMLTable()
+		table.defined = true
+		t.members[last] = table
+		return table, nil
+	}
+	table, isTable := existing.(*tomlTable)
+	if !isTable || table.defined {
+		return nil, p.errorAt(offset, "table '%v' is already defined", strings.Join(keys, "."))
+	}
+	table.defined = true
+	return table, nil
+}
+
+// parseKeyValue parses a key/value pair and adds it to table
+func (p *tomlParser) parseKeyValue(table *tomlTable) error {
+	start := p.offset
+	keys, err := p.parseDottedKey()
+	if err != nil {
+		return err
+	}
+	if p.offset >= len(p.data) {
+		return p.unexpectedEnd()
+	}
+	if p.data[p.offset] != '=' {
+		return p.errorf("invalid character %v after key, expected '='", p.currentChar())
+	}
+	p.offset++
+	p.skipWhitespace()
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	t := table
+	for _, key := range keys[:len(keys)-1] {
+		existing, ok := t.members[key]
+		if !ok {
+			child := newTOMLTable()
+			child.defined = true
+			child.dotted = true
+			t.members[key] = child
+			t = child
+			continue
+		}
+		child, isTable := existing.(*tomlTable)
+		if !isTable || !child.dotted {
+			return p.errorAt(start, "key '%v' is already defined", key)
+		}
+		t = child
+	}
+	last := keys[len(keys)-1]
+	if _, ok := t.members[last]; ok {
+		return p.errorAt(start, "key '%v' is already defined", strings.Join(keys, "."))
+	}
+	t.members[last] = value
+	return nil
+}
+
+// parseDottedKey parses a key such as a."b".c and the whitespace after it
+func (p *tomlParser) parseDottedKey() ([]string, error) {
+	var keys []string
+	for {
+		key, err := p.parseSimpleKey()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		p.skipWhitespace()
+		if p.offset >= len(p.data) || p.data[p.offset] != '.' {
+			return keys, nil
+		}
+		p.offset++
+		p.skipWhitespace()
+	}
+}
+
+func (p *tomlParser) parseSimpleKey() (string, error) {
+	if p.offset >= len(p.data) {
+		return "", p.unexpectedEnd()
+	}
+	switch p.data[p.offset] {
+	case '"':
+		return p.parseBasicString()
+	case '\'':
+		return p.parseLiteralString()
+	}
+	start := p.offset
+	for p.offset < len(p.data) && isTOMLBareKeyChar(p.data[p.offset]) {
+		p.offset++
+	}
+	if p.offset == start {
+		return "", p.errorf("invalid character %v in key", p.currentChar())
+	}
+	return string(p.data[start:p.offset]), nil
+}
+
+func (p *tomlParser) parseValue() (any, error) {
+	if p.offset >= len(p.data) {
+		return nil, p.unexpectedEnd()
+	}
+	switch p.data[p.offset] {
+	case '"':
+		if p.hasPrefix(`"""`) {
+			return p.parseMultilineString(`"`)
+		}
+		return p.parseBasicString()
+	case '\'':
+		if p.hasPrefix(`'''`) {
+			return p.parseMultilineString(`'`)
+		}
+		return p.parseLiteralString()
+	case '[':
+		return p.parseArray()
+	case '{':
+		return p.parseInlineTable()
+	}
+	if p.hasPrefix("true") {
+		p.offset += len("true")
+		return true, nil
+	}
+	if p.hasPrefix("false") {
+		p.offset += len("false")
+		return false, nil
+	}
+	if match := tomlDateTimeRegex.FindSubmatch(p.data[p.offset:]); match != nil {
+		return p.parseDateTime(match)
+	}
+	return p.parseNumber()
+}
+
+func (p *tomlParser) parseArray() (any, error) {
+	p.offset++
+	elements := make([]any, 0)
+	for {
+		err := p.skipArrayWhitespace()
+		if err != nil {
+			return nil, err
+		}
+		if p.offset < len(p.data) && p.data[p.offset] == ']' {
+			p.offset++
+			return elements, nil
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, value)
+		err = p.skipArrayWhitespace()
+		if err != nil {
+			return nil, err
+		}
+		if p.offset >= len(p.data) {
+			return nil, p.unexpectedEnd()
+		}
+		switch p.data[p.offset] {
+		case ',':
+			p.offset++
+		case ']':
+			p.offset++
+			return elements, nil
+		default:
+			return nil, p.errorf("invalid character %v after array element", p.currentChar())
+		}
+	}
+}
+
+func (p *tomlParser) parseInlineTable() (any, error) {
+	p.offset++
+	table := newTOMLTable()
+	p.skipWhitespace()
+	if p.offset < len(p.data) && p.data[p.offset] == '}' {
+		p.offset++
+		return table.toMap(), nil
+	}
+	for {
+		p.skipWhitespace()
+		err := p.parseKeyValue(table)
+		if err != nil {
+			return nil, err
+		}
+		p.skipWhitespace()
+		if p.offset >= len(p.data) {
+			return nil, p.unexpectedEnd()
+		}
+		switch p.data[p.offset] {
+		case ',':
+			p.offset++
+		case '}':
+			p.offset++
+			return table.toMap(), nil
+		default:
+			return nil, p.errorf("invalid character %v after inline table member", p.currentChar())
+		}
+	}
+}
+
+// parseBasicString parses a string in double quotes
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.offset++
+	var buf []byte
+	for p.offset < len(p.data) {
+		c := p.data[p.offset]
+		switch {
+		case c == '"':
+			p.offset++
+			return string(buf), nil
+		case c == '\\':
+			var err error
+			buf, err = p.appendEscape(buf)
+			if err != nil {
+				return "", err
+			}
+		case c == '\n' || c == '\r':
+			return "", p.errorf("newline in string")
+		case isTOMLControlChar(c):
+			return "", p.errorf("invalid character %v in string literal", p.currentChar())
+		default:
+			buf = append(buf, c)
+			p.offset++
+		}
+	}
+	return "", p.unexpectedEnd()
+}
+
+// parseLiteralString parses a string in single quotes, which has no escapes
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.offset++
+	start := p.offset
+	for p.offset < len(p.data) {
+		c := p.data[p.offset]
+		switch {
+		case c == '\'':
+			p.offset++
+			return string(p.data[start : p.offset-1]), nil
+		case c == '\n' || c == '\r':
+			return "", p.errorf("newline in string")
+		case isTOMLControlChar(c):
+			return "", p.errorf("invalid character %v in string literal", p.currentChar())
+		}
+		p.offset++
+	}
+	return "", p.unexpectedEnd()
+}
+
+// parseMultilineString parses a string in triple quotes. quote is `"` for basic strings, which have escapes,
+// and `'` for literal strings.
+func (p *tomlParser) parseMultilineString(quote string) (string, error) {
+	delimiter := strings.Repeat(quote, 3)
+	p.offset += len(delimiter)
+	// a newline right after the opening delimiter is trimmed
+	if p.hasPrefix("\n") {
+		p.offset++
+	} else if p.hasPrefix("\r\n") {
+		p.offset += 2
+	}
+	var buf []byte
+	for p.offset < len(p.data) {
+		c := p.data[p.offset]
+		switch {
+		case p.hasPrefix(delimiter):
+			// up to two quotes are allowed right before the closing delimiter
+			n := 0
+			for p.offset+n < len(p.data) && p.data[p.offset+n] == quote[0] {
+				n++
+			}
+			if n > 5 {
+				return "", p.errorf("too many quotes at the end of a multi-line string")
+			}
+			buf = append(buf, strings.Repeat(quote, n-3)...)
+			p.offset += n
+			return string(buf), nil
+		case c == '\\' && quote == `"`:
+			if p.skipLineEndingBackslash() {
+				continue
+			}
+			var err error
+			buf, err = p.appendEscape(buf)
+			if err != nil {
+				return "", err
+			}
+		case c == '\r' && !p.hasPrefix("\r\n"):
+			return "", p.errorf("invalid character %v in string literal", p.currentChar())
+		case c != '\n' && c != '\r' && isTOMLControlChar(c):
+			return "", p.errorf("invalid character %v in string literal", p.currentChar())
+		default:
+			buf = append(buf, c)
+			p.offset++
+		}
+	}
+	return "", p.unexpectedEnd()
+}
+
+// skipLineEndingBackslash skips a backslash at the end of a line in a multi-line basic string together with
+// all whitespace and newlines after it
+func (p *tomlParser) skipLineEndingBackslash() bool {
+	end := p.offset + 1
+	for end < len(p.data) && (p.data[end] == ' ' || p.data[end] == '\t') {
+		end++
+	}
+	if end >= len(p.data) || (p.data[end] != '\n' && p.data[end] != '\r') {
+		return false
+	}
+	for end < len(p.data) && bytes.IndexByte([]byte(" \t\r\n"), p.data[end]) >= 0 {
+		end++
+	}
+	p.offset = end
+	return true
+}
+
+// appendEscape appends the character of the escape sequence at the current offset to buf
+func (p *tomlParser) appendEscape(buf []byte) ([]byte, error) {
+	p.offset++
+	if p.offset >= len(p.data) {
+		return nil, p.unexpectedEnd()
+	}
+	c := p.data[p.offset]
+	p.offset++
+	switch c {
+	case 'b':
+		return append(buf, '\b'), nil
+	case 't':
+		return append(buf, '\t'), nil
+	case 'n':
+		return append(buf, '\n'), nil
+	case 'f':
+		return append(buf, '\f'), nil
+	case 'r':
+		return append(buf, '\r'), nil
+	case '"', '\\':
+		return append(buf, c), nil
+	case 'u', 'U':
+		n := 4
+		if c == 'U' {
+			n = 8
+		}
+		if p.offset+n > len(p.data) {
+			return nil, p.unexpectedEnd()
+		}
+		code, err := strconv.ParseUint(string(p.data[p.offset:p.offset+n]), 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return nil, p.errorf("invalid unicode escape \\%c%s", c, p.data[p.offset:p.offset+n])
+		}
+		p.offset += n
+		return utf8.AppendRune(buf, rune(code)), nil
+	}
+	p.offset--
+	return nil, p.errorf("invalid character %v in string escape code", p.currentChar())
+}
+
+// parseDateTime converts the datetime matched by tomlDateTimeRegex into a string that GetTime understands
+func (p *tomlParser) parseDateTime(match [][]byte) (any, error) {
+	date, clock, zone, localTime := string(match[1]), string(match[2]), string(match[3]), string(match[4])
+	var value, layout string
+	switch {
+	case localTime != "":
+		value, layout = localTime, time.TimeOnly
+	case zone != "":
+		value, layout = date+"T"+clock+strings.ToUpper(zone), time.RFC3339Nano
+	case clock != "":
+		value, layout = date+" "+clock, time.DateTime
+	default:
+		value, layout = date, time.DateOnly
+	}
+	if _, err := time.Parse(layout, value); err != nil {
+		return nil, p.errorf("invalid datetime %v", string(match[0]))
+	}
+	p.offset += len(match[0])
+	return value, nil
+}
+
+// parseNumber parses an integer or a float
+func (p *tomlParser) parseNumber() (any, error) {
+	start := p.offset
+	for p.offset < len(p.data) && isTOMLNumberChar(p.data[p.offset]) {
+		p.offset++
+	}
+	token := string(p.data[start:p.offset])
+	if token == "" {
+		return nil, p.errorf("invalid character %v looking for beginning of value", p.currentChar())
+	}
+	invalid := func() error {
+		return p.errorAt(start, "invalid number %v", token)
+	}
+	sign, body := "", token
+	if body[0] == '+' || body[0] == '-' {
+		sign, body = body[:1], body[1:]
+	}
+	if body == "inf" || body == "nan" {
+		return nil, p.errorAt(start, "%v cannot be represented in JSON", token)
+	}
+	if !validTOMLUnderscores(body) {
+		return nil, invalid()
+	}
+	body = strings.ReplaceAll(body, "_", "")
+
+	for prefix, base := range map[string]int{"0x": 16, "0o": 8, "0b": 2} {
+		if strings.HasPrefix(body, prefix) {
+			n, err := strconv.ParseInt(body[len(prefix):], base, 64)
+			if sign != "" || err != nil {
+				return nil, invalid()
+			}
+			return normalizeNumber(n), nil
+		}
+	}
+	intPart := body
+	if i := strings.IndexAny(body, ".eE"); i >= 0 {
+		intPart = body[:i]
+	}
+	if intPart == "" || strings.Trim(intPart, "0123456789") != "" || (len(intPart) > 1 && intPart[0] == '0') {
+		return nil, invalid()
+	}
+	if len(intPart) == len(body) {
+		n, err := strconv.ParseInt(sign+body, 10, 64)
+		if err != nil {
+			return nil, p.errorAt(start, "number %v out of range", token)
+		}
+		return normalizeNumber(n), nil
+	}
+	if i := strings.IndexByte(body, '.'); i >= 0 && (i+1 >= len(body) || !isDigit(body[i+1])) {
+		return nil, invalid()
+	}
+	f, err := strconv.ParseFloat(sign+body, 64)
+	if err != nil {
+		return nil, invalid()
+	}
+	return f, nil
+}
+
+func (p *tomlParser) skipWhitespace() {
+	for p.offset < len(p.data) && (p.data[p.offset] == ' ' || p.data[p.offset] == '\t') {
+		p.offset++
+	}
+}
+
+// skipArrayWhitespace skips whitespace, newlines and comments, which are allowed between array elements
+func (p *tomlParser) skipArrayWhitespace() error {
+	for p.offset < len(p.data) {
+		switch {
+		case p.data[p.offset] == ' ' || p.data[p.offset] == '\t' || p.data[p.offset] == '\n':
+			p.offset++
+		case p.hasPrefix("\r\n"):
+			p.offset += 2
+		case p.data[p.offset] == '#':
+			err := p.skipComment()
+			if err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// skipComment skips a comment up to the end of the line
+func (p *tomlParser) skipComment() error {
+	for p.offset < len(p.data) && p.data[p.offset] != '\n' && !p.hasPrefix("\r\n") {
+		if isTOMLControlChar(p.data[p.offset]) {
+			return p.errorf("invalid character %v in comment", p.currentChar())
+		}
+		p.offset++
+	}
+	return nil
+}
+
+func (p *tomlParser) hasPrefix(prefix string) bool {
+	return bytes.HasPrefix(p.data[p.offset:], []byte(prefix))
+}
+
+// currentChar returns the quoted character at the current offset for error messages
+func (p *tomlParser) currentChar() string {
+	r, _ := utf8.DecodeRune(p.data[p.offset:])
+	return quoteChar(r)
+}
+
+func (p *tomlParser) errorf(format string, args ...any) error {
+	return p.errorAt(p.offset, format, args...)
+}
+
+func (p *tomlParser) errorAt(offset int, format string, args ...any) error {
+	return newParseErrorAt(p.data, offset, fmt.Sprintf(format, args...), nil)
+}
+
+func (p *tomlParser) unexpectedEnd() error {
+	return newParseErrorAt(p.data, len(p.data), "unexpected end of TOML input", nil)
+}
+
+func isTOMLBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || isDigit(c) || c == '_' || c == '-'
+}
+
+func isTOMLNumberChar(c byte) bool {
+	return isTOMLBareKeyChar(c) || c == '+' || c == '.'
+}
+
+// isTOMLControlChar checks if c is a control character, which is not allowed in strings and comments
+func isTOMLControlChar(c byte) bool {
+	return c < 0x20 && c != '\t' || c == 0x7f
+}
+
+// validTOMLUnderscores checks that every underscore in a number is between two digits
+func validTOMLUnderscores(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '_' {
+			continue
+		}
+		if i == 0 || i == len(s)-1 {
+			return false
+		}
+		_, before := hexDigit(s[i-1])
+		_, after := hexDigit(s[i+1])
+		if !before || !after {
+			return false
+		}
+	}
+	return true
+}
+
+// writeTOMLTable writes the members of a table, followed by its tables and arrays of tables. keys are the
+// keys of the table's header and path is its path for error messages.
+func writeTOMLTable(sb *strings.Builder, keys []string, path Path, members map[string]*any) error {
+	var tables, tableArrays []string
+	for _, key := range sortedKeys(members) {
+		value := members[key]
+		if value == nil || *value == nil {
+			return createTOMLConversionErr(path.AppendKey(key).String(), "null cannot be represented in TOML")
+		}
+		if _, ok := toMemberPtrs(*value); ok {
+			tables = append(tables, key)
+			continue
+		}
+		if isTOMLTableArray(*value) {
+			tableArrays = append(tableArrays, key)
+			continue
+		}
+		text, err := tomlValue(*value, path.AppendKey(key))
+		if err != nil {
+			return err
+		}
+		sb.WriteString(tomlKey(key) + " = " + text + "\n")
+	}
+	for _, key := range tables {
+		childKeys := append(keys[:len(keys):len(keys)], tomlKey(key))
+		child, _ := toMemberPtrs(*members[key])
+		if len(child) == 0 || hasTOMLValues(child) {
+			writeTOMLHeader(sb, "["+strings.Join(childKeys, ".")+"]")
+		}
+		err := writeTOMLTable(sb, childKeys, path.AppendKey(key), child)
+		if err != nil {
+			return err
+		}
+	}
+	for _, key := range tableArrays {
+		// the headers of tables in an array of tables refer to its last element, so they have no index
+		childKeys := append(keys[:len(keys):len(keys)], tomlKey(key))
+		elements, _ := toElementPtrs(*members[key])
+		for i, element := range elements {
+			child, _ := toMemberPtrs(*element)
+			writeTOMLHeader(sb, "[["+strings.Join(childKeys, ".")+"]]")
+			err := writeTOMLTable(sb, childKeys, path.AppendKey(key).AppendIndex(i), child)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeTOMLHeader writes a table header, separated from the previous content by an empty line
+func writeTOMLHeader(sb *strings.Builder, header string) {
+	if sb.Len() > 0 {
+		sb.WriteByte('\n')
+	}
+	sb.WriteString(header + "\n")
+}
+
+// hasTOMLValues checks if a table has members that are written as key/value pairs rather than as tables
+func hasTOMLValues(members map[string]*any) bool {
+	for _, value := range members {
+		if value == nil || *value == nil {
+			return true
+		}
+		if _, ok := toMemberPtrs(*value); !ok && !isTOMLTableArray(*value) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTOMLTableArray checks if value is a non-empty array whose elements are all objects
+func isTOMLTableArray(value any) bool {
+	elements, ok := toElementPtrs(value)
+	if !ok || len(elements) == 0 {
+		return false
+	}
+	for _, element := range elements {
+		if element == nil {
+			return false
+		}
+		if _, ok := toMemberPtrs(*element); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// tomlValue returns the TOML text of a value that is written inline
+func tomlValue(value any, path Path) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", createTOMLConversionErr(path.String(), "null cannot be represented in TOML")
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case *big.Int:
+		if !v.IsInt64() {
+			return "", createTOMLConversionErr(path.String(), v.String()+" exceeds the 64-bit integers of TOML")
+		}
+		return v.String(), nil
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "nan", nil
+		case math.IsInf(v, 1):
+			return "inf", nil
+		case math.IsInf(v, -1):
+			return "-inf", nil
+		case v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64:
+			return strconv.FormatInt(int64(v), 10), nil
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case string:
+		return tomlString(v), nil
+	}
+	if members, ok := toMemberPtrs(value); ok {
+		if len(members) == 0 {
+			return "{}", nil
+		}
+		parts := make([]string, 0, len(members))
+		for _, key := range sortedKeys(members) {
+			var member any
+			if members[key] != nil {
+				member = *members[key]
+			}
+			text, err := tomlValue(member, path.AppendKey(key))
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, tomlKey(key)+" = "+text)
+		}
+		return "{ " + strings.Join(parts, ", ") + " }", nil
+	}
+	if elements, ok := toElementPtrs(value); ok {
+		parts := make([]string, 0, len(elements))
+		for i, element := range elements {
+			var e any
+			if element != nil {
+				e = *element
+			}
+			text, err := tomlValue(e, path.AppendIndex(i))
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, text)
+		}
+		return "[" + strings.Join(parts, ", ") + "]", nil
+	}
+	return "", createTOMLConversionErr(path.String(), fmt.Sprintf("%T cannot be represented in TOML", value))
+}
+
+// tomlKey returns key as a bare key if possible, or as a quoted key otherwise
+func tomlKey(key string) string {
+	if key == "" {
+		return `""`
+	}
+	for i := 0; i < len(key); i++ {
+		if !isTOMLBareKeyChar(key[i]) {
+			return tomlString(key)
+		}
+	}
+	return key
+}
+
+// tomlString returns s as a basic string in double quotes
+func tomlString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&sb, `\u%04X`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
@@ -0,0 +1,184 @@
+package jogson
+
+// WalkAction tells Walk how to continue after visiting a node
+type WalkAction int
+
+const (
+	// WalkContinue continues walking into the children of the current node
+	WalkContinue WalkAction = iota
+	// WalkSkip skips the children of the current node and continues with its next sibling
+	WalkSkip
+	// WalkStop stops walking
+	WalkStop
+)
+
+// TransformAction tells TransformValues what to do with the current node
+type TransformAction int
+
+const (
+	// TransformKeep keeps the current node and continues transforming its children
+	TransformKeep TransformAction = iota
+	// TransformReplace replaces the current node with the returned JsonMapper. The children of the
+	// replacement are not visited.
+	TransformReplace
+	// TransformDelete removes the current node from its parent object or array
+	TransformDelete
+)
+
+// WalkFunc is the function called by Walk for every node
+type WalkFunc func(path Path, value JsonMapper) WalkAction
+
+// TransformFunc is the function called by TransformValues for every node
+type TransformFunc func(path Path, value JsonMapper) (JsonMapper, TransformAction)
+
+// Walk visits every node of the JsonObject depth-first, starting with the object itself, and calls f with
+// the path and value of each node. Object keys are visited in ascending order.
+func (o *JsonObject) Walk(f WalkFunc) {
+	var root any = o.object
+	walkValue(Path{}, &root, f)
+}
+
+// Walk visits every node of the JsonArray depth-first, starting with the array itself, and calls f with
+// the path and value of each node.
+func (a *JsonArray) Walk(f WalkFunc) {
+	var root any = a.elements
+	walkValue(Path{}, &root, f)
+}
+
+// Walk visits every node of the JsonMapper depth-first, starting with the mapper itself, and calls f with
+// the path and value of each node.
+func (m *JsonMapper) Walk(f WalkFunc) {
+	root := convertMapperToAny(*m)
+	walkValue(Path{}, &root, f)
+}
+
+// TransformValues visits every node of the JsonObject depth-first and calls f with the path and value of
+// each node. Depending on the returned TransformAction, the node is kept, replaced or deleted in place.
+func (o *JsonObject) TransformValues(f TransformFunc) {
+	transformMembers(Path{}, o.object, f)
+}
+
+// TransformValues visits every node of the JsonArray depth-first and calls f with the path and value of
+// each node. Depending on the returned TransformAction, the node is kept, replaced or deleted in place.
+func (a *JsonArray) TransformValues(f TransformFunc) {
+	a.elements = transformElements(Path{}, a.elements, f)
+}
+
+// TransformValues visits every node of the JsonMapper depth-first and calls f with the path and value of
+// each node. Depending on the returned TransformAction, the node is kept, replaced or deleted in place.
+// If the JsonMapper is not an object or an array, nothing is done.
+func (m *JsonMapper) TransformValues(f TransformFunc) {
+	if m.kind == Object || m.kind == Array {
+		m.value = transformValue(Path{}, m.value, f)
+	}
+}
+
+// walkValue calls f for data and its children and returns false if walking should stop
+func walkValue(path Path, data *any, f WalkFunc) bool {
+	switch f(path, getMapperFromField(data)) {
+	case WalkStop:
+		return false
+	case WalkSkip:
+		return true
+	}
+	if data == nil {
+		return true
+	}
+	if members, ok := toMemberPtrs(*data); ok {
+		for _, key := range sortedKeys(members) {
+			if !walkValue(path.AppendKey(key), members[key], f) {
+				return false
+			}
+		}
+	} else if elements, ok := toElementPtrs(*data); ok {
+		for i, element := range elements {
+			if !walkValue(path.AppendIndex(i), element, f) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// transformValue transforms the children of value and returns the transformed value
+func transformValue(path Path, value any, f TransformFunc) any {
+	switch v := value.(type) {
+	case map[string]*any:
+		transformMembers(path, v, f)
+		return v
+	case map[string]any:
+		for _, key := range sortedKeys(v) {
+			child := v[key]
+			newChild, action := f(path.AppendKey(key), getMapperFromField(&child))
+			switch action {
+			case TransformDelete:
+				delete(v, key)
+			case TransformReplace:
+				v[key] = convertMapperToAny(newChild)
+			default:
+				v[key] = transformValue(path.AppendKey(key), child, f)
+			}
+		}
+		return v
+	case []any:
+		newElements := make([]any, 0, len(v))
+		for i, element := range v {
+			element := element
+			newElement, action := f(path.AppendIndex(i), getMapperFromField(&element))
+			switch action {
+			case TransformDelete:
+				continue
+			case TransformReplace:
+				newElements = append(newElements, convertMapperToAny(newElement))
+			default:
+				newElements = append(newElements, transformValue(path.AppendIndex(i), element, f))
+			}
+		}
+		return newElements
+	}
+	if elements, ok := toElementPtrs(value); ok {
+		return transformElements(path, elements, f)
+	}
+	return value
+}
+
+// transformMembers transforms the members of an object in place
+func transformMembers(path Path, members map[string]*any, f TransformFunc) {
+	for _, key := range sortedKeys(members) {
+		child := members[key]
+		newChild, action := f(path.AppendKey(key), getMapperFromField(child))
+		switch action {
+		case TransformDelete:
+			delete(members, key)
+		case TransformReplace:
+			members[key] = valuePtr(convertMapperToAny(newChild))
+		default:
+			if child != nil {
+				newValue := transformValue(path.AppendKey(key), *child, f)
+				members[key] = &newValue
+			}
+		}
+	}
+}
+
+// transformElements transforms the elements of an array and returns the new elements
+func transformElements(path Path, elements []*any, f TransformFunc) []*any {
+	newElements := make([]*any, 0, len(elements))
+	for i, element := range elements {
+		newElement, action := f(path.AppendIndex(i), getMapperFromField(element))
+		switch action {
+		case TransformDelete:
+			continue
+		case TransformReplace:
+			newElements = append(newElements, valuePtr(convertMapperToAny(newElement)))
+		default:
+			if element == nil {
+				newElements = append(newElements, nil)
+				continue
+			}
+			newValue := transformValue(path.AppendIndex(i), *element, f)
+			newElements = append(newElements, &newValue)
+		}
+	}
+	return newElements
+}
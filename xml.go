@@ -0,0 +1,280 @@
+package jogson
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	// XMLAttributePrefix is the prefix of the keys of XML attributes, e.g. "@id"
+	XMLAttributePrefix = "@"
+	// XMLTextKey is the key of the text of XML elements that have attributes or child elements
+	XMLTextKey = "#text"
+)
+
+// xmlNameRegex matches valid XML element and attribute names
+var xmlNameRegex = regexp.MustCompile(`^[\p{L}_:][\p{L}\p{N}_:.\-]*$`)
+
+// XMLOptions configures the conversion between XML and JsonObject
+type XMLOptions struct {
+	// ForceArray lists the names of elements that are always converted into arrays, even if they occur once
+	ForceArray []string
+	// Indent is the indentation used by ToXML. If empty, the XML is written in a single line.
+	Indent string
+}
+
+// NewObjectFromXML converts an XML document into a JsonObject using the following convention:
+//   - The object has a single key, the name of the root element.
+//   - An element without attributes and child elements is converted into its text, e.g. "<a>x</a>" into
+//     {"a": "x"}. Empty elements are converted into empty strings.
+//   - Other elements are converted into objects. Attributes are added with the key "@" + name, child
+//     elements with their name and the text with the key "#text", if it is not only whitespace.
+//   - Child elements with the same name are converted into an array. Elements listed in
+//     XMLOptions.ForceArray are always converted into arrays.
+//   - Namespace prefixes are kept in the names, e.g. "soap:Envelope", and namespace declarations are kept as
+//     attributes, e.g. "@xmlns:soap".
+//
+// All values are strings. Comments and processing instructions are ignored. If the XML is not well-formed,
+// ParseErr is returned.
+func NewObjectFromXML(data []byte, opts XMLOptions) (*JsonObject, error) {
+	forceArray := make(map[string]bool, len(opts.ForceArray))
+	for _, name := range opts.ForceArray {
+		forceArray[name] = true
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var stack []*xmlElement
+	root := make(map[string]any)
+	for {
+		offset := int(decoder.InputOffset())
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var syntaxErr *xml.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				return &JsonObject{}, newParseErrorAt(data, int(decoder.InputOffset()), syntaxErr.Msg, err)
+			}
+			return &JsonObject{}, newParseErrorAt(data, int(decoder.InputOffset()), err.Error(), err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 && len(root) > 0 {
+				return &JsonObject{}, newParseErrorAt(data, offset, "multiple root elements", nil)
+			}
+			element := &xmlElement{name: xmlName(t.Name), members: make(map[string]any)}
+			for _, attr := range t.Attr {
+				element.members[XMLAttributePrefix+xmlName(attr.Name)] = attr.Value
+			}
+			stack = append(stack, element)
+		case xml.EndElement:
+			if len(stack) == 0 || stack[len(stack)-1].name != xmlName(t.Name) {
+				return &JsonObject{}, newParseErrorAt(data, offset, "unexpected end element </"+xmlName(t.Name)+">", nil)
+			}
+			element := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				root[element.name] = element.value()
+			} else {
+				stack[len(stack)-1].addChild(element, forceArray[element.name])
+			}
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			} else if len(bytes.TrimSpace(t)) > 0 {
+				return &JsonObject{}, newParseErrorAt(data, offset, "text outside of the root element", nil)
+			}
+		}
+	}
+	if len(stack) > 0 {
+		return &JsonObject{}, newParseErrorAt(data, len(data), "unexpected end of XML input", nil)
+	}
+	if len(root) == 0 {
+		return &JsonObject{}, newParseErrorAt(data, len(data), "no root element", nil)
+	}
+	return newObjectFromMap(membersToPtrs(root)), nil
+}
+
+// NewObjectFromXMLFile reads an XML file from the given path and converts it into a JsonObject. See
+// NewObjectFromXML.
+func NewObjectFromXMLFile(path string, opts XMLOptions) (*JsonObject, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return &JsonObject{}, err
+	}
+	obj, err := NewObjectFromXML(file, opts)
+	return obj, withParseErrorPath(err, path)
+}
+
+// ToXML writes the JsonObject as an XML document to w, using the convention described in NewObjectFromXML.
+// The object must have a single key, which is the name of the root element, and its value must not be an
+// array. Arrays are written as repeated elements and null values as empty elements. Keys are written in
+// ascending order, attributes first. If the object cannot be represented as XML, e.g. because a key is not a
+// valid XML name, XMLConversionErr is returned. The XML declaration is not written.
+func (o *JsonObject) ToXML(w io.Writer, opts XMLOptions) error {
+	if len(o.object) != 1 {
+		return createXMLConversionErr("", "the object must have a single key, the root element")
+	}
+	var sb strings.Builder
+	for name, value := range o.object {
+		var v any
+		if value != nil {
+			v = *value
+		}
+		if _, ok := toElementPtrs(v); ok {
+			// an array would be written as multiple root elements, which is not well-formed
+			return createXMLConversionErr(Path{name}.String(), "the root element cannot be an array")
+		}
+		err := writeXMLElement(&sb, name, v, 0, Path{name}, opts)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// xmlElement is an element while converting XML
+type xmlElement struct {
+	name    string
+	members map[string]any
+	text    strings.Builder
+}
+
+// addChild adds the value of child to the members of the element. Children with the same name are
+// collected in an array.
+func (e *xmlElement) addChild(child *xmlElement, forceArray bool) {
+	value := child.value()
+	existing, ok := e.members[child.name]
+	switch {
+	case !ok && forceArray:
+		e.members[child.name] = []any{value}
+	case !ok:
+		e.members[child.name] = value
+	default:
+		if elements, isArray := existing.([]any); isArray {
+			e.members[child.name] = append(elements, value)
+		} else {
+			e.members[child.name] = []any{existing, value}
+		}
+	}
+}
+
+func (e *xmlElement) value() any {
+	if len(e.members) == 0 {
+		return e.text.String()
+	}
+	if text := strings.TrimSpace(e.text.String()); text != "" {
+		e.members[XMLTextKey] = text
+	}
+	return e.members
+}
+
+// xmlName returns the name of an element or attribute including its namespace prefix
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// writeXMLElement writes value as an element with the given name. Arrays are written as repeated elements.
+func writeXMLElement(sb *strings.Builder, name string, value any, depth int, path Path, opts XMLOptions) error {
+	if !xmlNameRegex.MatchString(name) {
+		return createXMLConversionErr(path.String(), "'"+name+"' is not a valid XML name")
+	}
+	if elements, ok := toElementPtrs(value); ok {
+		for i, element := range elements {
+			var e any
+			if element != nil {
+				e = *element
+			}
+			if _, isArray := toElementPtrs(e); isArray {
+				return createXMLConversionErr(path.AppendIndex(i).String(), "nested arrays cannot be represented in XML")
+			}
+			err := writeXMLElement(sb, name, e, depth, path.AppendIndex(i), opts)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	indent := strings.Repeat(opts.Indent, depth)
+	newline := ""
+	if opts.Indent != "" {
+		newline = "\n"
+	}
+	sb.WriteString(indent + "<" + name)
+	members, ok := toMemberPtrs(value)
+	if !ok {
+		if value == nil {
+			sb.WriteString("/>" + newline)
+			return nil
+		}
+		sb.WriteString(">" + xmlEscape(formatText(value, "")) + "</" + name + ">" + newline)
+		return nil
+	}
+
+	var children []string
+	for _, key := range sortedKeys(members) {
+		var member any
+		if members[key] != nil {
+			member = *members[key]
+		}
+		if !strings.HasPrefix(key, XMLAttributePrefix) {
+			if key != XMLTextKey {
+				children = append(children, key)
+			}
+			continue
+		}
+		attr := strings.TrimPrefix(key, XMLAttributePrefix)
+		if !xmlNameRegex.MatchString(attr) {
+			return createXMLConversionErr(path.AppendKey(key).String(), "'"+attr+"' is not a valid XML name")
+		}
+		_, isObject := toMemberPtrs(member)
+		_, isArray := toElementPtrs(member)
+		if isObject || isArray {
+			return createXMLConversionErr(path.AppendKey(key).String(), "attributes must be scalars")
+		}
+		sb.WriteString(" " + attr + `="` + xmlEscape(formatText(member, "")) + `"`)
+	}
+
+	var text string
+	if member, ok := members[XMLTextKey]; ok && member != nil {
+		text = xmlEscape(formatText(*member, ""))
+	}
+	if text == "" && len(children) == 0 {
+		sb.WriteString("/>" + newline)
+		return nil
+	}
+	sb.WriteString(">" + text)
+	if len(children) > 0 {
+		sb.WriteString(newline)
+		for _, key := range children {
+			var child any
+			if members[key] != nil {
+				child = *members[key]
+			}
+			err := writeXMLElement(sb, key, child, depth+1, path.AppendKey(key), opts)
+			if err != nil {
+				return err
+			}
+		}
+		sb.WriteString(indent)
+	}
+	sb.WriteString("</" + name + ">" + newline)
+	return nil
+}
+
+// xmlEscape escapes s for XML text and attribute values
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
@@ -0,0 +1,350 @@
+package jogson
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	yamlNullTag   = "!!null"
+	yamlBoolTag   = "!!bool"
+	yamlIntTag    = "!!int"
+	yamlFloatTag  = "!!float"
+	yamlBinaryTag = "!!binary"
+	yamlMergeTag  = "!!merge"
+	yamlIndent    = 2
+	// yamlMaxAliasNodes is the maximum number of nodes that aliases of a document may expand to, which stops
+	// documents like "billion laughs" whose aliases expand exponentially
+	yamlMaxAliasNodes = 1 << 20
+)
+
+// yamlErrorLineRegex matches the line that the yaml package adds to most syntax errors
+var yamlErrorLineRegex = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// NewMapperFromYAML parses YAML data into a JsonMapper. A stream with a single document is converted into the
+// value of the document and a stream with multiple documents, e.g. a Kubernetes manifest with several
+// resources separated by "---", into an array with one element per document. Anchors and aliases are
+// resolved, including merge keys ("<<"). Scalar keys that are not strings, e.g. numbers or bools, are
+// converted into their string form. Timestamps are kept as strings and binary values as base64 strings.
+// Values that cannot be represented as JSON, e.g. .inf, .nan or keys that are maps, return
+// YAMLConversionErr, as do aliases that expand to more than a million nodes. If data is not valid YAML, a
+// ParseError with the line of the error is returned.
+func NewMapperFromYAML(data []byte) (JsonMapper, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	var documents []any
+	for {
+		var node yaml.Node
+		err := decoder.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return JsonMapper{}, newYAMLParseError(data, err)
+		}
+		converter := yamlConverter{aliasBudget: yamlMaxAliasNodes}
+		value, err := converter.convertNode(&node)
+		if err != nil {
+			return JsonMapper{}, err
+		}
+		documents = append(documents, value)
+	}
+	switch len(documents) {
+	case 0:
+		return JsonMapper{kind: Null}, nil
+	case 1:
+		return newMapperFromParsed(documents[0]), nil
+	}
+	return newMapperFromParsed(documents), nil
+}
+
+// NewMapperFromYAMLFile reads a YAML file from the given path and parses it into a JsonMapper. See
+// NewMapperFromYAML.
+func NewMapperFromYAMLFile(path string) (JsonMapper, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return JsonMapper{}, err
+	}
+	mapper, err := NewMapperFromYAML(file)
+	return mapper, withParseErrorPath(err, path)
+}
+
+// NewObjectFromYAML parses a YAML document into a JsonObject. If the document is not a map, TypeConversionErr
+// is returned. See NewMapperFromYAML.
+func NewObjectFromYAML(data []byte) (*JsonObject, error) {
+	mapper, err := NewMapperFromYAML(data)
+	if err != nil {
+		return &JsonObject{}, err
+	}
+	return mapper.AsObject()
+}
+
+// NewObjectFromYAMLFile reads a YAML file from the given path and parses it into a JsonObject. See
+// NewObjectFromYAML.
+func NewObjectFromYAMLFile(path string) (*JsonObject, error) {
+	mapper, err := NewMapperFromYAMLFile(path)
+	if err != nil {
+		return &JsonObject{}, err
+	}
+	return mapper.AsObject()
+}
+
+// NewArrayFromYAML parses a YAML document into a JsonArray. If the document is not a sequence and the stream
+// does not contain multiple documents, TypeConversionErr is returned. See NewMapperFromYAML.
+func NewArrayFromYAML(data []byte) (*JsonArray, error) {
+	mapper, err := NewMapperFromYAML(data)
+	if err != nil {
+		return &JsonArray{}, err
+	}
+	return mapper.AsArray()
+}
+
+// YAMLString returns a YAML representation of the JsonObject. Keys are sorted.
+func (o *JsonObject) YAMLString() string {
+	return toYAMLString(o.object)
+}
+
+// YAMLString returns a YAML representation of the JsonArray.
+func (a *JsonArray) YAMLString() string {
+	return toYAMLString(a.elements)
+}
+
+// YAMLString returns a YAML representation of the JsonMapper underlying value. Keys are sorted.
+func (m *JsonMapper) YAMLString() string {
+	return toYAMLString(m.value)
+}
+
+func toYAMLString(v any) string {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(yamlIndent)
+	_ = encoder.Encode(yamlValue(v))
+	_ = encoder.Close()
+	return buf.String()
+}
+
+// newYAMLParseError returns a ParseError for a syntax error of the yaml package. Most of its errors start
+// with the line, e.g. "yaml: line 3: did not find expected key", but not with the column, so the error is
+// placed at the start of the line.
+func newYAMLParseError(data []byte, err error) error {
+	match := yamlErrorLineRegex.FindStringSubmatch(err.Error())
+	if match == nil {
+		return &ParseError{Message: err.Error(), Err: err}
+	}
+	line, _ := strconv.Atoi(match[1])
+	offset := 0
+	for i := 1; i < line; i++ {
+		next := bytes.IndexByte(data[offset:], '\n')
+		if next < 0 {
+			break
+		}
+		offset += next + 1
+	}
+	return newParseErrorAt(data, offset, strings.TrimPrefix(err.Error(), match[0]), err)
+}
+
+// yamlValue returns a copy of value in which integers that do not fit into int are replaced by YAML nodes.
+// Otherwise, yaml.v3 would write them as quoted strings.
+func yamlValue(value any) any {
+	if n, ok := value.(*big.Int); ok {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: yamlIntTag, Value: n.String()}
+	}
+	if members, ok := toMemberPtrs(value); ok {
+		converted := make(map[string]any, len(members))
+		for key, member := range members {
+			converted[key] = yamlValue(derefValue(member))
+		}
+		return converted
+	}
+	if elements, ok := toElementPtrs(value); ok {
+		converted := make([]any, len(elements))
+		for i, element := range elements {
+			converted[i] = yamlValue(derefValue(element))
+		}
+		return converted
+	}
+	return value
+}
+
+// yamlConverter converts the nodes of a YAML document into the values used internally to represent JSON
+type yamlConverter struct {
+	// visiting holds the nodes currently being converted, which is used to detect recursive aliases
+	visiting []*yaml.Node
+	// aliasDepth is the number of aliases the current node is reached through
+	aliasDepth int
+	// aliasBudget is the number of nodes that may still be converted through aliases
+	aliasBudget int
+}
+
+// convertNode converts a YAML node into the values used internally to represent JSON
+func (c *yamlConverter) convertNode(node *yaml.Node) (any, error) {
+	for _, n := range c.visiting {
+		if n == node {
+			return nil, createYAMLConversionErr(node.Line, node.Column, "recursive alias")
+		}
+	}
+	if c.aliasDepth > 0 {
+		c.aliasBudget--
+		if c.aliasBudget < 0 {
+			return nil, createYAMLConversionErr(node.Line, node.Column,
+				fmt.Sprintf("aliases expand to more than %v nodes", yamlMaxAliasNodes))
+		}
+	}
+	c.visiting = append(c.visiting, node)
+	defer func() { c.visiting = c.visiting[:len(c.visiting)-1] }()
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return c.convertNode(node.Content[0])
+	case yaml.AliasNode:
+		c.aliasDepth++
+		defer func() { c.aliasDepth-- }()
+		return c.convertNode(node.Alias)
+	case yaml.SequenceNode:
+		elements := make([]any, 0, len(node.Content))
+		for _, child := range node.Content {
+			value, err := c.convertNode(child)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, value)
+		}
+		return elements, nil
+	case yaml.MappingNode:
+		members := make(map[string]any)
+		err := c.convertMapping(node, members)
+		if err != nil {
+			return nil, err
+		}
+		return members, nil
+	case yaml.ScalarNode:
+		return convertYAMLScalar(node)
+	}
+	return nil, createYAMLConversionErr(node.Line, node.Column, "unknown node")
+}
+
+// convertMapping adds the members of a mapping node to members. Members of maps merged with "<<" are only
+// added if they are not defined in the mapping itself.
+func (c *yamlConverter) convertMapping(node *yaml.Node, members map[string]any) error {
+	var merged []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		if keyNode.Kind == yaml.ScalarNode && keyNode.ShortTag() == yamlMergeTag {
+			merged = append(merged, valueNode)
+			continue
+		}
+		key, err := convertYAMLKey(keyNode)
+		if err != nil {
+			return err
+		}
+		value, err := c.convertNode(valueNode)
+		if err != nil {
+			return err
+		}
+		members[key] = value
+	}
+	for _, m := range merged {
+		err := c.mergeMapping(m, members)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeMapping merges the value of a merge key, which is a map or a sequence of maps, into members
+func (c *yamlConverter) mergeMapping(node *yaml.Node, members map[string]any) error {
+	resolved := node
+	for resolved.Kind == yaml.AliasNode {
+		resolved = resolved.Alias
+	}
+	var sources []*yaml.Node
+	switch resolved.Kind {
+	case yaml.MappingNode:
+		sources = []*yaml.Node{node}
+	case yaml.SequenceNode:
+		sources = resolved.Content
+		if resolved != node {
+			// the maps of an aliased sequence count towards the alias budget
+			c.aliasDepth++
+			defer func() { c.aliasDepth-- }()
+		}
+	default:
+		return createYAMLConversionErr(node.Line, node.Column, "merge value is not a map")
+	}
+	for _, source := range sources {
+		value, err := c.convertNode(source)
+		if err != nil {
+			return err
+		}
+		sourceMembers, ok := value.(map[string]any)
+		if !ok {
+			return createYAMLConversionErr(source.Line, source.Column, "merge value is not a map")
+		}
+		for k, v := range sourceMembers {
+			if _, ok := members[k]; !ok {
+				members[k] = v
+			}
+		}
+	}
+	return nil
+}
+
+// convertYAMLKey converts a map key into a string. Scalars are converted into their string form, e.g. 1 into
+// "1" and ~ into "null". Maps and sequences cannot be represented as JSON keys.
+func convertYAMLKey(node *yaml.Node) (string, error) {
+	resolved := node
+	for resolved.Kind == yaml.AliasNode {
+		resolved = resolved.Alias
+	}
+	if resolved.Kind != yaml.ScalarNode {
+		return "", createYAMLConversionErr(node.Line, node.Column, "map keys must be scalars")
+	}
+	if resolved.ShortTag() == yamlNullTag {
+		return "null", nil
+	}
+	return resolved.Value, nil
+}
+
+func convertYAMLScalar(node *yaml.Node) (any, error) {
+	switch node.ShortTag() {
+	case yamlNullTag:
+		return nil, nil
+	case yamlBoolTag:
+		var b bool
+		err := node.Decode(&b)
+		if err != nil {
+			return nil, createYAMLConversionErr(node.Line, node.Column, err.Error())
+		}
+		return b, nil
+	case yamlIntTag, yamlFloatTag:
+		var n any
+		err := node.Decode(&n)
+		if err != nil {
+			return nil, createYAMLConversionErr(node.Line, node.Column, err.Error())
+		}
+		if f, ok := n.(float64); ok && (math.IsInf(f, 0) || math.IsNaN(f)) {
+			return nil, createYAMLConversionErr(node.Line, node.Column, fmt.Sprintf("%v is not a valid JSON number", node.Value))
+		}
+		if _, ok := n.(string); ok {
+			// explicitly tagged values that are not numbers, e.g. !!int abc
+			return nil, createYAMLConversionErr(node.Line, node.Column, fmt.Sprintf("%v is not a valid number", node.Value))
+		}
+		return normalizeNumber(n), nil
+	case yamlBinaryTag:
+		return strings.Join(strings.Fields(node.Value), ""), nil
+	}
+	// strings, timestamps and values with custom tags, e.g. !Ref, are kept as written
+	return node.Value, nil
+}